@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	ErrNoPath   = errors.New("no path")
+	ErrDirected = errors.New("graph is directed")
+)
+
+type Weight interface {
+	constraints.Integer | constraints.Float
+}
+
+type Edge[V comparable, W Weight] struct {
+	From   V
+	To     V
+	Weight W
+}
+
+// Graph is implemented by AdjacencyList and AdjacencyMatrix. An undirected graph returns every edge from both of its
+// vertices.
+type Graph[V comparable, W Weight] interface {
+	Directed() bool
+	Vertices() []V
+	HasVertex(v V) bool
+	Neighbours(v V) []Edge[V, W]
+}
+
+// Path is a path from Vertices[0] to the last vertex, Cost is the sum of its edge weights.
+type Path[V comparable, W Weight] struct {
+	Vertices []V
+	Cost     W
+}
+
+// Tree is a minimum spanning tree, or a forest when the graph is not connected.
+type Tree[V comparable, W Weight] struct {
+	Edges []Edge[V, W]
+	Cost  W
+}
+
+func vertexNotFoundError(v any) error {
+	return fmt.Errorf(`vertex %v not found`, v)
+}
+
+func negativeWeightError(from, to any) error {
+	return fmt.Errorf(`edge %v-%v has a negative weight`, from, to)
+}
+
+func invalidSizeError(n int) error {
+	return fmt.Errorf(`n must not be negative, got %v`, n)
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"sync"
+)
+
+// AdjacencyList stores the edges of every vertex in a list, vertices are returned in the order they were added.
+type AdjacencyList[V comparable, W Weight] struct {
+	directed bool
+	vertices []V
+	edges    map[V][]Edge[V, W]
+	mu       sync.RWMutex
+}
+
+func (g *AdjacencyList[V, W]) Directed() bool {
+	return g.directed
+}
+
+func (g *AdjacencyList[V, W]) Vertices() []V {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	vertices := make([]V, len(g.vertices))
+	copy(vertices, g.vertices)
+
+	return vertices
+}
+
+func (g *AdjacencyList[V, W]) HasVertex(v V) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, ok := g.edges[v]
+	return ok
+}
+
+func (g *AdjacencyList[V, W]) Neighbours(v V) []Edge[V, W] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	edges := make([]Edge[V, W], len(g.edges[v]))
+	copy(edges, g.edges[v])
+
+	return edges
+}
+
+func (g *AdjacencyList[V, W]) AddVertex(v V) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.addVertex(v)
+}
+
+// AddEdge adds the vertices when they do not exist, and replaces the weight of an existing edge.
+func (g *AdjacencyList[V, W]) AddEdge(from, to V, weight W) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.addVertex(from)
+	g.addVertex(to)
+	g.setEdge(Edge[V, W]{From: from, To: to, Weight: weight})
+
+	if !g.directed && from != to {
+		g.setEdge(Edge[V, W]{From: to, To: from, Weight: weight})
+	}
+}
+
+func (g *AdjacencyList[V, W]) RemoveEdge(from, to V) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.removeEdge(from, to)
+	if !g.directed {
+		g.removeEdge(to, from)
+	}
+}
+
+func (g *AdjacencyList[V, W]) addVertex(v V) {
+	if _, ok := g.edges[v]; ok {
+		return
+	}
+
+	g.vertices = append(g.vertices, v)
+	g.edges[v] = make([]Edge[V, W], 0)
+}
+
+func (g *AdjacencyList[V, W]) setEdge(edge Edge[V, W]) {
+	edges := g.edges[edge.From]
+	for i := range edges {
+		if edges[i].To == edge.To {
+			edges[i].Weight = edge.Weight
+			return
+		}
+	}
+
+	g.edges[edge.From] = append(edges, edge)
+}
+
+func (g *AdjacencyList[V, W]) removeEdge(from, to V) {
+	edges := g.edges[from]
+	for i := range edges {
+		if edges[i].To == to {
+			g.edges[from] = append(edges[:i], edges[i+1:]...)
+			return
+		}
+	}
+}
+
+func NewAdjacencyList[V comparable, W Weight](directed bool) *AdjacencyList[V, W] {
+	return &AdjacencyList[V, W]{
+		directed: directed,
+		vertices: make([]V, 0),
+		edges:    make(map[V][]Edge[V, W]),
+	}
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdjacencyList(t *testing.T) {
+	cases := map[string]struct {
+		directed   bool
+		edges      []Edge[string, int]
+		remove     [][2]string
+		vertices   []string
+		neighbours map[string][]Edge[string, int]
+	}{
+		"undirected graph": {
+			edges: []Edge[string, int]{
+				{From: "A", To: "B", Weight: 1},
+				{From: "B", To: "C", Weight: 2},
+				{From: "A", To: "B", Weight: 3},
+			},
+			vertices: []string{"A", "B", "C"},
+			neighbours: map[string][]Edge[string, int]{
+				"A": {{From: "A", To: "B", Weight: 3}},
+				"B": {{From: "B", To: "A", Weight: 3}, {From: "B", To: "C", Weight: 2}},
+				"C": {{From: "C", To: "B", Weight: 2}},
+				"D": {},
+			},
+		},
+		"directed graph": {
+			directed: true,
+			edges: []Edge[string, int]{
+				{From: "A", To: "B", Weight: 1},
+				{From: "B", To: "C", Weight: 2},
+				{From: "C", To: "A", Weight: 3},
+			},
+			remove:   [][2]string{{"C", "A"}},
+			vertices: []string{"A", "B", "C"},
+			neighbours: map[string][]Edge[string, int]{
+				"A": {{From: "A", To: "B", Weight: 1}},
+				"B": {{From: "B", To: "C", Weight: 2}},
+				"C": {},
+			},
+		},
+		"remove undirected edge": {
+			edges: []Edge[string, int]{
+				{From: "A", To: "B", Weight: 1},
+				{From: "B", To: "C", Weight: 2},
+			},
+			remove:   [][2]string{{"B", "A"}},
+			vertices: []string{"A", "B", "C"},
+			neighbours: map[string][]Edge[string, int]{
+				"A": {},
+				"B": {{From: "B", To: "C", Weight: 2}},
+				"C": {{From: "C", To: "B", Weight: 2}},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			g := NewAdjacencyList[string, int](tc.directed)
+			for _, e := range tc.edges {
+				g.AddEdge(e.From, e.To, e.Weight)
+			}
+
+			for _, e := range tc.remove {
+				g.RemoveEdge(e[0], e[1])
+			}
+
+			a.Equal(tc.directed, g.Directed())
+			a.Equal(tc.vertices, g.Vertices())
+			for v, edges := range tc.neighbours {
+				a.Equal(edges, g.Neighbours(v))
+			}
+
+			a.False(g.HasVertex("D"))
+			g.AddVertex("D")
+			a.True(g.HasVertex("D"))
+		})
+	}
+}
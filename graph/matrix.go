@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"sync"
+)
+
+// AdjacencyMatrix stores the edges of n vertices, numbered from 0 to n-1, in an n by n matrix.
+type AdjacencyMatrix[W Weight] struct {
+	directed bool
+	weights  [][]W
+	present  [][]bool
+	mu       sync.RWMutex
+}
+
+func (g *AdjacencyMatrix[W]) Directed() bool {
+	return g.directed
+}
+
+func (g *AdjacencyMatrix[W]) Vertices() []int {
+	vertices := make([]int, len(g.weights))
+	for i := range vertices {
+		vertices[i] = i
+	}
+
+	return vertices
+}
+
+func (g *AdjacencyMatrix[W]) HasVertex(v int) bool {
+	return v >= 0 && v < len(g.weights)
+}
+
+func (g *AdjacencyMatrix[W]) Neighbours(v int) []Edge[int, W] {
+	edges := make([]Edge[int, W], 0)
+	if !g.HasVertex(v) {
+		return edges
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for to, ok := range g.present[v] {
+		if ok {
+			edges = append(edges, Edge[int, W]{From: v, To: to, Weight: g.weights[v][to]})
+		}
+	}
+
+	return edges
+}
+
+// AddEdge adds an edge or replaces the weight of an existing one.
+func (g *AdjacencyMatrix[W]) AddEdge(from, to int, weight W) error {
+	if err := g.check(from, to); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.setEdge(from, to, weight, true)
+	if !g.directed {
+		g.setEdge(to, from, weight, true)
+	}
+
+	return nil
+}
+
+func (g *AdjacencyMatrix[W]) RemoveEdge(from, to int) error {
+	if err := g.check(from, to); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var empty W
+	g.setEdge(from, to, empty, false)
+	if !g.directed {
+		g.setEdge(to, from, empty, false)
+	}
+
+	return nil
+}
+
+func (g *AdjacencyMatrix[W]) check(vertices ...int) error {
+	for _, v := range vertices {
+		if !g.HasVertex(v) {
+			return vertexNotFoundError(v)
+		}
+	}
+
+	return nil
+}
+
+func (g *AdjacencyMatrix[W]) setEdge(from, to int, weight W, present bool) {
+	g.weights[from][to] = weight
+	g.present[from][to] = present
+}
+
+func NewAdjacencyMatrix[W Weight](n int, directed bool) *AdjacencyMatrix[W] {
+	if n < 0 {
+		panic(invalidSizeError(n))
+	}
+
+	weights := make([][]W, n)
+	present := make([][]bool, n)
+	for i := range weights {
+		weights[i] = make([]W, n)
+		present[i] = make([]bool, n)
+	}
+
+	return &AdjacencyMatrix[W]{directed: directed, weights: weights, present: present}
+}
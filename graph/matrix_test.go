@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdjacencyMatrix(t *testing.T) {
+	cases := map[string]struct {
+		n          int
+		directed   bool
+		edges      []Edge[int, float64]
+		remove     [][2]int
+		neighbours map[int][]Edge[int, float64]
+		err        error
+	}{
+		"undirected graph": {
+			n: 3,
+			edges: []Edge[int, float64]{
+				{From: 0, To: 1, Weight: 1.5},
+				{From: 1, To: 2, Weight: 2},
+				{From: 0, To: 1, Weight: 0.5},
+			},
+			neighbours: map[int][]Edge[int, float64]{
+				0: {{From: 0, To: 1, Weight: 0.5}},
+				1: {{From: 1, To: 0, Weight: 0.5}, {From: 1, To: 2, Weight: 2}},
+				2: {{From: 2, To: 1, Weight: 2}},
+				3: {},
+			},
+		},
+		"directed graph": {
+			n:        3,
+			directed: true,
+			edges: []Edge[int, float64]{
+				{From: 0, To: 1, Weight: 1},
+				{From: 1, To: 2, Weight: 2},
+				{From: 2, To: 0, Weight: 3},
+			},
+			remove: [][2]int{{2, 0}},
+			neighbours: map[int][]Edge[int, float64]{
+				0: {{From: 0, To: 1, Weight: 1}},
+				1: {{From: 1, To: 2, Weight: 2}},
+				2: {},
+			},
+		},
+		"add edge to vertex not found": {
+			n: 2,
+			edges: []Edge[int, float64]{
+				{From: 0, To: 2, Weight: 1},
+			},
+			neighbours: map[int][]Edge[int, float64]{
+				0: {},
+			},
+			err: errors.New("vertex 2 not found"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			g := NewAdjacencyMatrix[float64](tc.n, tc.directed)
+
+			var err error
+			for _, e := range tc.edges {
+				if e := g.AddEdge(e.From, e.To, e.Weight); e != nil {
+					err = e
+				}
+			}
+
+			for _, e := range tc.remove {
+				a.Nil(g.RemoveEdge(e[0], e[1]))
+			}
+
+			a.Equal(tc.err, err)
+			a.Equal(tc.directed, g.Directed())
+			a.Len(g.Vertices(), tc.n)
+			for v, edges := range tc.neighbours {
+				a.Equal(edges, g.Neighbours(v))
+			}
+		})
+	}
+}
+
+func TestNewAdjacencyMatrix_InvalidSize(t *testing.T) {
+	assert.PanicsWithError(t, "n must not be negative, got -1", func() {
+		NewAdjacencyMatrix[int](-1, false)
+	})
+}
@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"github.com/CameronXie/algorithms-go/sort/merge"
+	"github.com/CameronXie/algorithms-go/tree/heap"
+)
+
+type primItem[V comparable, W Weight] struct {
+	edge Edge[V, W]
+}
+
+// Prim returns the minimum spanning tree of an undirected graph, growing it from every vertex not yet in the tree, so
+// a graph which is not connected gets a minimum spanning forest. Every vertex outside the tree is queued by the
+// lightest edge reaching it, and a lighter edge decreases its key in place.
+func Prim[V comparable, W Weight](g Graph[V, W]) (Tree[V, W], error) {
+	if g.Directed() {
+		return Tree[V, W]{}, ErrDirected
+	}
+
+	mst := Tree[V, W]{Edges: make([]Edge[V, W], 0)}
+	visited := make(map[V]bool)
+	frontier := heap.NewFunc(4, []primItem[V, W]{},
+		func(item primItem[V, W]) V {
+			return item.edge.To
+		},
+		func(a, b primItem[V, W]) bool {
+			return a.edge.Weight < b.edge.Weight
+		},
+	)
+
+	visit := func(v V) {
+		visited[v] = true
+		for _, edge := range g.Neighbours(v) {
+			if visited[edge.To] {
+				continue
+			}
+
+			next := primItem[V, W]{edge: edge}
+			if queued, ok := frontier.Find(edge.To); !ok {
+				_ = frontier.Push(next)
+			} else if edge.Weight < queued.edge.Weight {
+				_ = frontier.Update(edge.To, func(primItem[V, W]) primItem[V, W] {
+					return next
+				})
+			}
+		}
+	}
+
+	for _, root := range g.Vertices() {
+		if visited[root] {
+			continue
+		}
+
+		visit(root)
+		for item, ok := frontier.Pop(); ok; item, ok = frontier.Pop() {
+			mst.Edges = append(mst.Edges, item.edge)
+			mst.Cost += item.edge.Weight
+			visit(item.edge.To)
+		}
+	}
+
+	return mst, nil
+}
+
+type kruskalEdge[V comparable, W Weight] Edge[V, W]
+
+func (e kruskalEdge[V, W]) Less(i any) bool {
+	return e.Weight < i.(kruskalEdge[V, W]).Weight
+}
+
+// Kruskal returns the minimum spanning tree of an undirected graph, or a minimum spanning forest when the graph is
+// not connected. Edges are taken from the lightest, skipping those joining two vertices already in the same set.
+func Kruskal[V comparable, W Weight](g Graph[V, W]) (Tree[V, W], error) {
+	if g.Directed() {
+		return Tree[V, W]{}, ErrDirected
+	}
+
+	vertices := g.Vertices()
+	edges := make([]kruskalEdge[V, W], 0)
+	for _, v := range vertices {
+		for _, edge := range g.Neighbours(v) {
+			edges = append(edges, kruskalEdge[V, W](edge))
+		}
+	}
+
+	mst := Tree[V, W]{Edges: make([]Edge[V, W], 0)}
+	sets := newDisjointSet(vertices)
+	for _, edge := range merge.Sort(edges) {
+		if len(mst.Edges) == len(vertices)-1 {
+			break
+		}
+
+		if sets.union(edge.From, edge.To) {
+			mst.Edges = append(mst.Edges, Edge[V, W](edge))
+			mst.Cost += edge.Weight
+		}
+	}
+
+	return mst, nil
+}
+
+// disjointSet is a union-find with path compression and union by rank.
+type disjointSet[V comparable] struct {
+	parent map[V]V
+	rank   map[V]int
+}
+
+func (s *disjointSet[V]) find(v V) V {
+	root := v
+	for s.parent[root] != root {
+		root = s.parent[root]
+	}
+
+	for v != root {
+		v, s.parent[v] = s.parent[v], root
+	}
+
+	return root
+}
+
+// union joins the sets of a and b, it returns false when they are already in the same set.
+func (s *disjointSet[V]) union(a, b V) bool {
+	a, b = s.find(a), s.find(b)
+	if a == b {
+		return false
+	}
+
+	if s.rank[a] < s.rank[b] {
+		a, b = b, a
+	}
+
+	s.parent[b] = a
+	if s.rank[a] == s.rank[b] {
+		s.rank[a]++
+	}
+
+	return true
+}
+
+func newDisjointSet[V comparable](vertices []V) *disjointSet[V] {
+	s := &disjointSet[V]{parent: make(map[V]V, len(vertices)), rank: make(map[V]int, len(vertices))}
+	for _, v := range vertices {
+		s.parent[v] = v
+	}
+
+	return s
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestMinimumSpanningTree(t *testing.T) {
+	cases := map[string]struct {
+		directed bool
+		vertices []string
+		edges    []Edge[string, int]
+		expected [][2]string
+		cost     int
+		err      error
+	}{
+		"spanning tree": {
+			edges:    getTestEdges(),
+			expected: [][2]string{{"A", "B"}, {"A", "C"}, {"C", "F"}, {"D", "E"}, {"E", "F"}},
+			cost:     33,
+		},
+		"spanning forest": {
+			vertices: []string{"X"},
+			edges: []Edge[string, int]{
+				{From: "A", To: "B", Weight: 1},
+				{From: "B", To: "C", Weight: 3},
+				{From: "A", To: "C", Weight: 2},
+				{From: "D", To: "E", Weight: 5},
+			},
+			expected: [][2]string{{"A", "B"}, {"A", "C"}, {"D", "E"}},
+			cost:     8,
+		},
+		"empty graph": {
+			expected: [][2]string{},
+		},
+		"directed graph": {
+			directed: true,
+			edges:    getTestEdges(),
+			expected: [][2]string{},
+			err:      ErrDirected,
+		},
+	}
+
+	algorithms := map[string]func(g Graph[string, int]) (Tree[string, int], error){
+		"Prim":    Prim[string, int],
+		"Kruskal": Kruskal[string, int],
+	}
+
+	for n, tc := range cases {
+		for name, algorithm := range algorithms {
+			t.Run(name+" "+n, func(t *testing.T) {
+				a := assert.New(t)
+				g := NewAdjacencyList[string, int](tc.directed)
+				for _, v := range tc.vertices {
+					g.AddVertex(v)
+				}
+
+				for _, e := range tc.edges {
+					g.AddEdge(e.From, e.To, e.Weight)
+				}
+
+				mst, err := algorithm(g)
+				a.Equal(tc.err, err)
+				a.Equal(tc.cost, mst.Cost)
+				a.ElementsMatch(tc.expected, getPairs(mst.Edges))
+			})
+		}
+	}
+}
+
+func TestMinimumSpanningTree_Random(t *testing.T) {
+	cases := map[string]struct {
+		n     int
+		edges int
+	}{
+		"sparse graph": {
+			n:     50,
+			edges: 80,
+		},
+		"dense graph": {
+			n:     30,
+			edges: 400,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			g := NewAdjacencyMatrix[int](tc.n, false)
+			for i := 0; i < tc.edges; i++ {
+				_ = g.AddEdge(rand.Intn(tc.n), rand.Intn(tc.n), rand.Intn(100))
+			}
+
+			prim, err := Prim[int, int](g)
+			a.Nil(err)
+
+			kruskal, err := Kruskal[int, int](g)
+			a.Nil(err)
+
+			a.Equal(kruskal.Cost, prim.Cost)
+			a.Equal(len(kruskal.Edges), len(prim.Edges))
+		})
+	}
+}
+
+func getPairs(edges []Edge[string, int]) [][2]string {
+	pairs := make([][2]string, 0)
+	for _, e := range edges {
+		if e.From > e.To {
+			e.From, e.To = e.To, e.From
+		}
+
+		pairs = append(pairs, [2]string{e.From, e.To})
+	}
+
+	return pairs
+}
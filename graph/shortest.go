@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"github.com/CameronXie/algorithms-go/tree/heap"
+)
+
+// Heuristic estimates the cost from v to the target, it must never overestimate the cost for AStar to return the
+// shortest path.
+type Heuristic[V comparable, W Weight] func(v V) W
+
+type searchItem[V comparable, W Weight] struct {
+	vertex   V
+	cost     W
+	estimate W
+}
+
+// Dijkstra returns the shortest path from source to target, edge weights must not be negative.
+func Dijkstra[V comparable, W Weight](g Graph[V, W], source, target V) (Path[V, W], error) {
+	return AStar(g, source, target, func(V) W {
+		var zero W
+		return zero
+	})
+}
+
+// AStar returns the shortest path from source to target, visiting the vertices in the order of their cost from
+// source plus the heuristic estimate to target. The frontier is a heap keyed by vertex, so a cheaper path to a queued
+// vertex decreases its key in place. A vertex is queued again when a cheaper path is found after it is visited,
+// which keeps the result correct for heuristics that are admissible but not consistent.
+func AStar[V comparable, W Weight](g Graph[V, W], source, target V, h Heuristic[V, W]) (Path[V, W], error) {
+	for _, v := range []V{source, target} {
+		if !g.HasVertex(v) {
+			return Path[V, W]{}, vertexNotFoundError(v)
+		}
+	}
+
+	costs := map[V]W{source: 0}
+	prev := make(map[V]V)
+	frontier := heap.NewFunc(4, []searchItem[V, W]{{vertex: source, estimate: h(source)}},
+		func(item searchItem[V, W]) V {
+			return item.vertex
+		},
+		func(a, b searchItem[V, W]) bool {
+			return a.estimate < b.estimate
+		},
+	)
+
+	for current, ok := frontier.Pop(); ok; current, ok = frontier.Pop() {
+		if current.vertex == target {
+			return Path[V, W]{Vertices: pathTo(prev, source, target), Cost: current.cost}, nil
+		}
+
+		for _, edge := range g.Neighbours(current.vertex) {
+			if edge.Weight < 0 {
+				return Path[V, W]{}, negativeWeightError(edge.From, edge.To)
+			}
+
+			cost := current.cost + edge.Weight
+			if old, ok := costs[edge.To]; ok && old <= cost {
+				continue
+			}
+
+			costs[edge.To] = cost
+			prev[edge.To] = current.vertex
+			next := searchItem[V, W]{vertex: edge.To, cost: cost, estimate: cost + h(edge.To)}
+
+			if frontier.Update(edge.To, func(searchItem[V, W]) searchItem[V, W] {
+				return next
+			}) != nil {
+				_ = frontier.Push(next)
+			}
+		}
+	}
+
+	return Path[V, W]{}, ErrNoPath
+}
+
+func pathTo[V comparable](prev map[V]V, source, target V) []V {
+	path := []V{target}
+	for v := target; v != source; {
+		v = prev[v]
+		path = append(path, v)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
@@ -0,0 +1,233 @@
+package graph
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	cases := map[string]struct {
+		directed bool
+		edges    []Edge[string, int]
+		source   string
+		target   string
+		expected Path[string, int]
+		err      error
+	}{
+		"shortest path": {
+			edges:    getTestEdges(),
+			source:   "A",
+			target:   "E",
+			expected: Path[string, int]{Vertices: []string{"A", "C", "F", "E"}, Cost: 20},
+		},
+		"path to source": {
+			edges:    getTestEdges(),
+			source:   "A",
+			target:   "A",
+			expected: Path[string, int]{Vertices: []string{"A"}, Cost: 0},
+		},
+		"no path in directed graph": {
+			directed: true,
+			edges:    []Edge[string, int]{{From: "A", To: "B", Weight: 1}, {From: "C", To: "B", Weight: 1}},
+			source:   "A",
+			target:   "C",
+			err:      ErrNoPath,
+		},
+		"vertex not found": {
+			edges:  getTestEdges(),
+			source: "A",
+			target: "Z",
+			err:    errors.New("vertex Z not found"),
+		},
+		"negative weight": {
+			edges:  []Edge[string, int]{{From: "A", To: "B", Weight: -1}},
+			source: "A",
+			target: "B",
+			err:    errors.New("edge A-B has a negative weight"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			g := NewAdjacencyList[string, int](tc.directed)
+			for _, e := range tc.edges {
+				g.AddEdge(e.From, e.To, e.Weight)
+			}
+
+			path, err := Dijkstra[string, int](g, tc.source, tc.target)
+			a.Equal(tc.err, err)
+			a.Equal(tc.expected, path)
+		})
+	}
+}
+
+func TestAStar(t *testing.T) {
+	cases := map[string]struct {
+		width  int
+		walls  []int
+		source int
+		target int
+		cost   int
+		err    error
+	}{
+		"path around walls": {
+			width:  4,
+			walls:  []int{1, 5, 9, 6},
+			source: 0,
+			target: 3,
+			cost:   9,
+		},
+		"no path through walls": {
+			width:  3,
+			walls:  []int{1, 4, 7},
+			source: 0,
+			target: 2,
+			err:    ErrNoPath,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			g := getTestGrid(tc.width, tc.walls)
+			manhattan := func(v int) int {
+				return abs(v%tc.width-tc.target%tc.width) + abs(v/tc.width-tc.target/tc.width)
+			}
+
+			path, err := AStar[int, int](g, tc.source, tc.target, manhattan)
+			a.Equal(tc.err, err)
+			a.Equal(tc.cost, path.Cost)
+
+			expected, _ := Dijkstra[int, int](g, tc.source, tc.target)
+			a.Equal(expected.Cost, path.Cost)
+
+			if err == nil {
+				a.Equal(tc.source, path.Vertices[0])
+				a.Equal(tc.target, path.Vertices[len(path.Vertices)-1])
+				a.Len(path.Vertices, tc.cost+1)
+			}
+		})
+	}
+}
+
+func TestDijkstra_Random(t *testing.T) {
+	cases := map[string]struct {
+		n     int
+		edges int
+	}{
+		"sparse graph": {
+			n:     50,
+			edges: 100,
+		},
+		"dense graph": {
+			n:     30,
+			edges: 600,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			g := NewAdjacencyMatrix[int](tc.n, true)
+			for i := 0; i < tc.edges; i++ {
+				_ = g.AddEdge(rand.Intn(tc.n), rand.Intn(tc.n), rand.Intn(100))
+			}
+
+			expected := floydWarshall(g)
+			for target := 0; target < tc.n; target++ {
+				path, err := Dijkstra[int, int](g, 0, target)
+				if expected[target] < 0 {
+					a.Equal(ErrNoPath, err)
+					continue
+				}
+
+				a.Nil(err)
+				a.Equal(expected[target], path.Cost)
+			}
+		})
+	}
+}
+
+func floydWarshall(g *AdjacencyMatrix[int]) []int {
+	n := len(g.Vertices())
+	dist := make([][]int, n)
+	for i := range dist {
+		dist[i] = make([]int, n)
+		for j := range dist[i] {
+			dist[i][j] = -1
+		}
+
+		dist[i][i] = 0
+		for _, e := range g.Neighbours(i) {
+			if e.To != i {
+				dist[i][e.To] = e.Weight
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if dist[i][k] < 0 || dist[k][j] < 0 {
+					continue
+				}
+
+				if d := dist[i][k] + dist[k][j]; dist[i][j] < 0 || d < dist[i][j] {
+					dist[i][j] = d
+				}
+			}
+		}
+	}
+
+	return dist[0]
+}
+
+func getTestEdges() []Edge[string, int] {
+	return []Edge[string, int]{
+		{From: "A", To: "B", Weight: 7},
+		{From: "A", To: "C", Weight: 9},
+		{From: "A", To: "F", Weight: 14},
+		{From: "B", To: "C", Weight: 10},
+		{From: "B", To: "D", Weight: 15},
+		{From: "C", To: "D", Weight: 11},
+		{From: "C", To: "F", Weight: 2},
+		{From: "D", To: "E", Weight: 6},
+		{From: "E", To: "F", Weight: 9},
+	}
+}
+
+// getTestGrid returns a width by width grid with edges of weight 1 between neighbouring cells which are not walls.
+func getTestGrid(width int, walls []int) *AdjacencyMatrix[int] {
+	isWall := make(map[int]bool)
+	for _, w := range walls {
+		isWall[w] = true
+	}
+
+	g := NewAdjacencyMatrix[int](width*width, false)
+	for v := 0; v < width*width; v++ {
+		if isWall[v] {
+			continue
+		}
+
+		if right := v + 1; v%width < width-1 && !isWall[right] {
+			_ = g.AddEdge(v, right, 1)
+		}
+
+		if down := v + width; down < width*width && !isWall[down] {
+			_ = g.AddEdge(v, down, 1)
+		}
+	}
+
+	return g
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+
+	return i
+}
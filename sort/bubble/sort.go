@@ -1,16 +1,36 @@
 package bubble
 
 import (
+	"golang.org/x/exp/constraints"
 	"sort"
 )
 
+// Sort is stable, it only swaps neighbours which are out of order.
 func Sort(data sort.Interface) {
 	n := data.Len()
 	for i := n; i > 1; i-- {
 		for j := 0; j < i-1; j++ {
-			if !data.Less(j, j+1) {
+			if data.Less(j+1, j) {
 				data.Swap(j, j+1)
 			}
 		}
 	}
 }
+
+// SortFunc is stable like Sort.
+func SortFunc[T any](s []T, less func(a, b T) bool) {
+	n := len(s)
+	for i := n; i > 1; i-- {
+		for j := 0; j < i-1; j++ {
+			if less(s[j+1], s[j]) {
+				s[j], s[j+1] = s[j+1], s[j]
+			}
+		}
+	}
+}
+
+func SortOrdered[T constraints.Ordered](s []T) {
+	SortFunc(s, func(a, b T) bool {
+		return a < b
+	})
+}
@@ -1,6 +1,7 @@
 package bubble
 
 import (
+	"github.com/CameronXie/algorithms-go/sort/internal/sorttest"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -47,3 +48,84 @@ func (l testData) Less(i, j int) bool {
 func (l testData) Swap(i, j int) {
 	l[i], l[j] = l[j], l[i]
 }
+
+func TestSortFunc(t *testing.T) {
+	cases := map[string]struct {
+		input    []testItem
+		expected []testItem
+	}{
+		"sort by key": {
+			input: []testItem{
+				{key: 3, value: "C"},
+				{key: 1, value: "A"},
+				{key: 4, value: "D"},
+				{key: 2, value: "B"},
+			},
+			expected: []testItem{
+				{key: 1, value: "A"},
+				{key: 2, value: "B"},
+				{key: 3, value: "C"},
+				{key: 4, value: "D"},
+			},
+		},
+		"sort empty list": {
+			input:    []testItem{},
+			expected: []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortFunc(tc.input, func(a, b testItem) bool {
+				return a.key < b.key
+			})
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortOrdered(t *testing.T) {
+	cases := map[string]struct {
+		input    []string
+		expected []string
+	}{
+		"sort strings": {
+			input:    []string{"pear", "apple", "fig", "banana", "apple"},
+			expected: []string{"apple", "apple", "banana", "fig", "pear"},
+		},
+		"sort single item": {
+			input:    []string{"fig"},
+			expected: []string{"fig"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortOrdered(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+type testItem struct {
+	key   int
+	value string
+}
+
+func TestSort_Stability(t *testing.T) {
+	t.Run("Sort", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			Sort(sorttest.Data(items))
+		}, true)
+	})
+
+	t.Run("SortFunc", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			SortFunc(items, sorttest.Less)
+		}, true)
+	})
+}
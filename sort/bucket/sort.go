@@ -0,0 +1,101 @@
+package bucket
+
+import (
+	"github.com/CameronXie/algorithms-go/sort/merge"
+	"golang.org/x/exp/constraints"
+	"math"
+)
+
+const insertionSortThreshold = 16
+
+type keyed[T any, K constraints.Float] struct {
+	key  K
+	item T
+}
+
+func Sort[T constraints.Float](s []T) {
+	SortBy(s, func(item T) T {
+		return item
+	})
+}
+
+// SortBy is a stable bucket sort of s by the float key. The range from the smallest to the largest finite key is
+// split into len(s) buckets, which are sorted by insertion sort when small or merge sort otherwise, so uniformly
+// distributed keys take O(n) on average. Infinite keys go to the first or the last bucket, and NaN keys go last.
+func SortBy[T any, K constraints.Float](s []T, key func(item T) K) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	items := make([]keyed[T, K], n)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, item := range s {
+		items[i] = keyed[T, K]{key: key(item), item: item}
+		if k := float64(items[i].key); !math.IsInf(k, 0) && !math.IsNaN(k) {
+			lo, hi = math.Min(lo, k), math.Max(hi, k)
+		}
+	}
+
+	buckets := make([]int, n)
+	starts := make([]int, n+2)
+	for i, item := range items {
+		buckets[i] = index(float64(item.key), lo, hi, n)
+		starts[buckets[i]+1]++
+	}
+
+	for b := 1; b < len(starts); b++ {
+		starts[b] += starts[b-1]
+	}
+
+	sorted := make([]keyed[T, K], n)
+	next := make([]int, n+1)
+	copy(next, starts)
+	for i, item := range items {
+		sorted[next[buckets[i]]] = item
+		next[buckets[i]]++
+	}
+
+	less := func(a, b keyed[T, K]) bool {
+		return a.key < b.key
+	}
+
+	for b := 0; b < n; b++ {
+		if bucket := sorted[starts[b]:starts[b+1]]; len(bucket) > insertionSortThreshold {
+			merge.SortFunc(bucket, less)
+		} else {
+			insertionSort(bucket, less)
+		}
+	}
+
+	for i := range sorted {
+		s[i] = sorted[i].item
+	}
+}
+
+func insertionSort[T any](s []T, less func(a, b T) bool) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && less(s[j], s[j-1]); j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// index returns the bucket of k, buckets 0 to n-1 split the range from lo to hi, and bucket n holds NaN.
+func index(k, lo, hi float64, n int) int {
+	switch {
+	case math.IsNaN(k):
+		return n
+	case k <= lo:
+		return 0
+	case k >= hi:
+		return n - 1
+	}
+
+	b := int((k - lo) / (hi - lo) * float64(n))
+	if b >= n {
+		return n - 1
+	}
+
+	return b
+}
@@ -0,0 +1,156 @@
+package bucket
+
+import (
+	"github.com/CameronXie/algorithms-go/sort/internal/sorttest"
+	"github.com/CameronXie/algorithms-go/sort/quick"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := map[string]struct {
+		input    []float64
+		expected []float64
+	}{
+		"sort random list": {
+			input:    []float64{0.6, 0.2, 0.7, 0.1, 0.9, 1, 0.8, 0.3, 0.5, 0.4},
+			expected: []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1},
+		},
+		"sort infinite items": {
+			input:    []float64{1.5, math.Inf(1), -2, math.Inf(-1), 0},
+			expected: []float64{math.Inf(-1), -2, 0, 1.5, math.Inf(1)},
+		},
+		"sort equal items": {
+			input:    []float64{2, 2, 2},
+			expected: []float64{2, 2, 2},
+		},
+		"sort empty list": {
+			input:    []float64{},
+			expected: []float64{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			Sort(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSort_NaN(t *testing.T) {
+	a := assert.New(t)
+	input := []float64{3, math.NaN(), 1, math.NaN(), 2}
+
+	Sort(input)
+	a.Equal([]float64{1, 2, 3}, input[:3])
+	a.True(math.IsNaN(input[3]))
+	a.True(math.IsNaN(input[4]))
+}
+
+func TestSort_Random(t *testing.T) {
+	cases := map[string]struct {
+		random func() float64
+	}{
+		"uniform distribution": {
+			random: rand.Float64,
+		},
+		"exponential distribution": {
+			random: rand.ExpFloat64,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			input := make([]float64, 10000)
+			for i := range input {
+				input[i] = tc.random()
+			}
+
+			expected := make([]float64, len(input))
+			copy(expected, input)
+			sort.Float64s(expected)
+
+			Sort(input)
+			a.Equal(expected, input)
+		})
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	cases := map[string]struct {
+		input    []testItem
+		expected []testItem
+	}{
+		"keep the order of equal keys": {
+			input: []testItem{
+				{key: 0.3, value: "A"},
+				{key: -1, value: "B"},
+				{key: 0.3, value: "C"},
+				{key: 100, value: "D"},
+				{key: -1, value: "E"},
+				{key: 0.3, value: "F"},
+			},
+			expected: []testItem{
+				{key: -1, value: "B"},
+				{key: -1, value: "E"},
+				{key: 0.3, value: "A"},
+				{key: 0.3, value: "C"},
+				{key: 0.3, value: "F"},
+				{key: 100, value: "D"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortBy(tc.input, func(item testItem) float32 {
+				return item.key
+			})
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	input := make([]float64, 1<<20)
+	for i := range input {
+		input[i] = rand.Float64()
+	}
+	data := make([]float64, len(input))
+
+	b.Run("bucket", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			Sort(data)
+		}
+	})
+
+	b.Run("quick", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			quick.Sort(sort.Float64Slice(data))
+		}
+	})
+}
+
+type testItem struct {
+	key   float32
+	value string
+}
+
+func TestSortBy_Stability(t *testing.T) {
+	sorttest.Check(t, func(items []sorttest.Item) {
+		SortBy(items, func(item sorttest.Item) float64 {
+			return float64(item.Key)
+		})
+	}, true)
+}
@@ -0,0 +1,63 @@
+package counting
+
+import (
+	"github.com/CameronXie/algorithms-go/sort/internal/sortkey"
+	"github.com/CameronXie/algorithms-go/sort/radix"
+	"golang.org/x/exp/constraints"
+	"math"
+)
+
+// MaxRange is the largest range of keys, from the smallest to the largest, which is sorted by counting. Wider ranges are
+// sorted by radix.SortBy instead.
+const MaxRange = 1 << 26
+
+func Sort[T constraints.Integer](s []T) {
+	SortBy(s, func(item T) T {
+		return item
+	})
+}
+
+// SortBy is a stable counting sort of s by the integer key, it takes O(n+k) time and space for k keys from the
+// smallest to the largest, so it suits keys in a small range. Keys in a range larger than MaxRange are sorted by the
+// stable radix.SortBy instead.
+func SortBy[T any, K constraints.Integer](s []T, key func(item T) K) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	keys := make([]uint64, n)
+	lo, hi := uint64(math.MaxUint64), uint64(0)
+	for i, item := range s {
+		keys[i] = sortkey.Integer(key(item))
+		if keys[i] < lo {
+			lo = keys[i]
+		}
+
+		if keys[i] > hi {
+			hi = keys[i]
+		}
+	}
+
+	if hi-lo >= MaxRange {
+		radix.SortBy(s, key)
+		return
+	}
+
+	offsets := make([]int, hi-lo+1)
+	for _, k := range keys {
+		offsets[k-lo]++
+	}
+
+	for i, total := 0, 0; i < len(offsets); i++ {
+		offsets[i], total = total, total+offsets[i]
+	}
+
+	sorted := make([]T, n)
+	for i, k := range keys {
+		sorted[offsets[k-lo]] = s[i]
+		offsets[k-lo]++
+	}
+
+	copy(s, sorted)
+}
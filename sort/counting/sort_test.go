@@ -0,0 +1,143 @@
+package counting
+
+import (
+	"github.com/CameronXie/algorithms-go/sort/internal/sorttest"
+	"github.com/CameronXie/algorithms-go/sort/quick"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := map[string]struct {
+		input    []int
+		expected []int
+	}{
+		"sort random list": {
+			input:    []int{6, 2, 7, 1, 9, 10, 8, 3, 5, 4},
+			expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		"sort negative items": {
+			input:    []int{3, -1, 0, -3, 3, -1},
+			expected: []int{-3, -1, -1, 0, 3, 3},
+		},
+		"sort items near the limits": {
+			input:    []int{math.MaxInt64, math.MaxInt64 - 2, math.MaxInt64 - 1},
+			expected: []int{math.MaxInt64 - 2, math.MaxInt64 - 1, math.MaxInt64},
+		},
+		"sort keys wider than MaxRange": {
+			input:    []int{MaxRange, 3, 0, -MaxRange},
+			expected: []int{-MaxRange, 0, 3, MaxRange},
+		},
+		"sort empty list": {
+			input:    []int{},
+			expected: []int{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			Sort(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortBy_RangeTooLarge(t *testing.T) {
+	a := assert.New(t)
+	input := []testItem{
+		{key: MaxRange, value: "A"},
+		{key: 0, value: "B"},
+		{key: math.MinInt64, value: "C"},
+		{key: MaxRange, value: "D"},
+		{key: 0, value: "E"},
+	}
+
+	SortBy(input, func(item testItem) int {
+		return item.key
+	})
+
+	a.Equal([]testItem{
+		{key: math.MinInt64, value: "C"},
+		{key: 0, value: "B"},
+		{key: 0, value: "E"},
+		{key: MaxRange, value: "A"},
+		{key: MaxRange, value: "D"},
+	}, input)
+}
+
+func TestSortBy(t *testing.T) {
+	cases := map[string]struct {
+		input    []testItem
+		expected []testItem
+	}{
+		"keep the order of equal keys": {
+			input: []testItem{
+				{key: 3, value: "A"},
+				{key: -1, value: "B"},
+				{key: 3, value: "C"},
+				{key: 100, value: "D"},
+				{key: -1, value: "E"},
+				{key: 3, value: "F"},
+			},
+			expected: []testItem{
+				{key: -1, value: "B"},
+				{key: -1, value: "E"},
+				{key: 3, value: "A"},
+				{key: 3, value: "C"},
+				{key: 3, value: "F"},
+				{key: 100, value: "D"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortBy(tc.input, func(item testItem) int8 {
+				return int8(item.key)
+			})
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	input := make([]int, 1<<20)
+	for i := range input {
+		input[i] = rand.Intn(1000)
+	}
+	data := make([]int, len(input))
+
+	b.Run("counting", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			Sort(data)
+		}
+	})
+
+	b.Run("quick", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			quick.Sort(sort.IntSlice(data))
+		}
+	})
+}
+
+type testItem struct {
+	key   int
+	value string
+}
+
+func TestSortBy_Stability(t *testing.T) {
+	sorttest.Check(t, func(items []sorttest.Item) {
+		SortBy(items, func(item sorttest.Item) int {
+			return item.Key
+		})
+	}, true)
+}
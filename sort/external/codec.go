@@ -0,0 +1,209 @@
+package external
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const maxRecordSize = 1 << 30
+
+// The sizes of the headers of a string and a slice, which are held in memory besides their bytes.
+const (
+	stringHeaderSize = 16
+	sliceHeaderSize  = 24
+)
+
+// Codec reads and writes a stream of records, it is used for the input, the spilled runs and the output.
+type Codec[T any] interface {
+	NewDecoder(r io.Reader) Decoder[T]
+	NewEncoder(w io.Writer) Encoder[T]
+}
+
+// Decoder returns the records of a stream one by one, and io.EOF after the last record.
+type Decoder[T any] interface {
+	Decode() (T, error)
+}
+
+// Encoder writes records to a stream, they may be buffered until Flush.
+type Encoder[T any] interface {
+	Encode(item T) error
+	Flush() error
+}
+
+// Sizer is implemented by codecs which can estimate the bytes of memory held by a record, Sort limits the records in
+// memory to Options.MemoryBytes with it.
+type Sizer[T any] interface {
+	Size(item T) int
+}
+
+// Lines is a Codec of newline terminated lines, the newline is not part of the record. The last line may miss its
+// newline, and a "\r" before the newline is kept in the record so CRLF files are written back unchanged.
+type Lines struct{}
+
+func (Lines) NewDecoder(r io.Reader) Decoder[string] {
+	return &linesDecoder{r: bufio.NewReader(r)}
+}
+
+func (Lines) NewEncoder(w io.Writer) Encoder[string] {
+	return &linesEncoder{w: bufio.NewWriter(w)}
+}
+
+func (Lines) Size(line string) int {
+	return stringHeaderSize + len(line)
+}
+
+type linesDecoder struct {
+	r *bufio.Reader
+}
+
+func (d *linesDecoder) Decode() (string, error) {
+	line, err := d.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		return line, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+type linesEncoder struct {
+	w *bufio.Writer
+}
+
+func (e *linesEncoder) Encode(line string) error {
+	if _, err := e.w.WriteString(line); err != nil {
+		return err
+	}
+
+	return e.w.WriteByte('\n')
+}
+
+func (e *linesEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+// LengthPrefixed is a Codec of binary records, each one prefixed by its length as an unsigned varint.
+type LengthPrefixed struct{}
+
+func (LengthPrefixed) NewDecoder(r io.Reader) Decoder[[]byte] {
+	return &lengthPrefixedDecoder{r: bufio.NewReader(r)}
+}
+
+func (LengthPrefixed) NewEncoder(w io.Writer) Encoder[[]byte] {
+	return &lengthPrefixedEncoder{w: bufio.NewWriter(w)}
+}
+
+func (LengthPrefixed) Size(data []byte) int {
+	return sliceHeaderSize + cap(data)
+}
+
+type lengthPrefixedDecoder struct {
+	r *bufio.Reader
+}
+
+func (d *lengthPrefixedDecoder) Decode() ([]byte, error) {
+	size, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > maxRecordSize {
+		return nil, recordTooLargeError(size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+type lengthPrefixedEncoder struct {
+	w      *bufio.Writer
+	header [binary.MaxVarintLen64]byte
+}
+
+func (e *lengthPrefixedEncoder) Encode(data []byte) error {
+	n := binary.PutUvarint(e.header[:], uint64(len(data)))
+	if _, err := e.w.Write(e.header[:n]); err != nil {
+		return err
+	}
+
+	_, err := e.w.Write(data)
+	return err
+}
+
+func (e *lengthPrefixedEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+// CSV is a Codec of CSV records, Comma is the field delimiter and defaults to ','. Records may have different
+// numbers of fields.
+type CSV struct {
+	Comma rune
+}
+
+func (c CSV) NewDecoder(r io.Reader) Decoder[[]string] {
+	d := csv.NewReader(r)
+	d.FieldsPerRecord = -1
+	if c.Comma != 0 {
+		d.Comma = c.Comma
+	}
+
+	return csvDecoder{r: d}
+}
+
+func (c CSV) NewEncoder(w io.Writer) Encoder[[]string] {
+	e := csv.NewWriter(w)
+	if c.Comma != 0 {
+		e.Comma = c.Comma
+	}
+
+	return csvEncoder{w: e}
+}
+
+func (CSV) Size(record []string) int {
+	size := sliceHeaderSize + cap(record)*stringHeaderSize
+	for _, field := range record {
+		size += len(field)
+	}
+
+	return size
+}
+
+type csvDecoder struct {
+	r *csv.Reader
+}
+
+func (d csvDecoder) Decode() ([]string, error) {
+	return d.r.Read()
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e csvEncoder) Encode(record []string) error {
+	return e.w.Write(record)
+}
+
+func (e csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func recordTooLargeError(size uint64) error {
+	return fmt.Errorf(`record of %v bytes is larger than %v`, size, maxRecordSize)
+}
@@ -0,0 +1,199 @@
+package external
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	cases := map[string]struct {
+		input    string
+		expected []string
+		output   string
+	}{
+		"lines": {
+			input:    "b\na\n\nc\n",
+			expected: []string{"b", "a", "", "c"},
+			output:   "b\na\n\nc\n",
+		},
+		"last line without newline": {
+			input:    "b\na",
+			expected: []string{"b", "a"},
+			output:   "b\na\n",
+		},
+		"crlf": {
+			input:    "b\r\na\r\n",
+			expected: []string{"b\r", "a\r"},
+			output:   "b\r\na\r\n",
+		},
+		"empty": {
+			input:    "",
+			expected: []string{},
+			output:   "",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			records, err := decodeAll[string](Lines{}, strings.NewReader(tc.input))
+			a.NoError(err)
+			a.Equal(tc.expected, records)
+
+			var buf bytes.Buffer
+			enc := Lines{}.NewEncoder(&buf)
+			a.NoError(encodeAll(enc, records))
+			a.NoError(enc.Flush())
+			a.Equal(tc.output, buf.String())
+		})
+	}
+}
+
+func TestLengthPrefixed(t *testing.T) {
+	cases := map[string]struct {
+		records [][]byte
+	}{
+		"records": {
+			records: [][]byte{[]byte("b"), {}, bytes.Repeat([]byte{0, '\n', 0xff}, 100)},
+		},
+		"empty": {
+			records: [][]byte{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			var buf bytes.Buffer
+			enc := LengthPrefixed{}.NewEncoder(&buf)
+			a.NoError(encodeAll(enc, tc.records))
+			a.NoError(enc.Flush())
+
+			records, err := decodeAll[[]byte](LengthPrefixed{}, &buf)
+			a.NoError(err)
+			a.Equal(tc.records, records)
+		})
+	}
+}
+
+func TestLengthPrefixed_Truncated(t *testing.T) {
+	cases := map[string]struct {
+		input    []byte
+		expected error
+	}{
+		"truncated data": {
+			input:    []byte{3, 'a', 'b'},
+			expected: io.ErrUnexpectedEOF,
+		},
+		"missing data": {
+			input:    []byte{3},
+			expected: io.ErrUnexpectedEOF,
+		},
+		"truncated length": {
+			input:    []byte{0x80},
+			expected: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			_, err := LengthPrefixed{}.NewDecoder(bytes.NewReader(tc.input)).Decode()
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestLengthPrefixed_TooLarge(t *testing.T) {
+	_, err := LengthPrefixed{}.NewDecoder(bytes.NewReader([]byte{0x80, 0x80, 0x80, 0x80, 0x08})).Decode()
+	assert.EqualError(t, err, `record of 2147483648 bytes is larger than 1073741824`)
+}
+
+func TestSizer(t *testing.T) {
+	cases := map[string]struct {
+		size     func() int
+		expected int
+	}{
+		"line": {
+			size: func() int {
+				return Lines{}.Size("abc")
+			},
+			expected: 19,
+		},
+		"length prefixed": {
+			size: func() int {
+				return LengthPrefixed{}.Size(make([]byte, 3, 8))
+			},
+			expected: 32,
+		},
+		"csv": {
+			size: func() int {
+				return CSV{}.Size([]string{"ab", "", "cde"})
+			},
+			expected: 77,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.size())
+		})
+	}
+}
+
+func TestCSV(t *testing.T) {
+	cases := map[string]struct {
+		codec    CSV
+		input    string
+		expected [][]string
+		output   string
+	}{
+		"quoted fields": {
+			codec:    CSV{},
+			input:    "b,\"x,y\"\na,\"line\nbreak\",3\n",
+			expected: [][]string{{"b", "x,y"}, {"a", "line\nbreak", "3"}},
+			output:   "b,\"x,y\"\na,\"line\nbreak\",3\n",
+		},
+		"tab separated": {
+			codec:    CSV{Comma: '\t'},
+			input:    "b\t2\na\t1\n",
+			expected: [][]string{{"b", "2"}, {"a", "1"}},
+			output:   "b\t2\na\t1\n",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			records, err := decodeAll[[]string](tc.codec, strings.NewReader(tc.input))
+			a.NoError(err)
+			a.Equal(tc.expected, records)
+
+			var buf bytes.Buffer
+			enc := tc.codec.NewEncoder(&buf)
+			a.NoError(encodeAll(enc, records))
+			a.NoError(enc.Flush())
+			a.Equal(tc.output, buf.String())
+		})
+	}
+}
+
+func decodeAll[T any](codec Codec[T], r io.Reader) ([]T, error) {
+	dec := codec.NewDecoder(r)
+	records := make([]T, 0)
+
+	for {
+		record, err := dec.Decode()
+		if err == io.EOF {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+}
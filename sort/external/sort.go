@@ -0,0 +1,268 @@
+package external
+
+import (
+	"fmt"
+	"github.com/CameronXie/algorithms-go/sort/merge"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	defaultMemoryLimit = 1 << 20
+	defaultMemoryBytes = 64 << 20
+	defaultFanIn       = 64
+)
+
+type Options struct {
+	// MemoryLimit is the number of records sorted in memory at once, 1048576 by default. Larger inputs are spilled to
+	// sorted runs of at most this many records.
+	MemoryLimit int
+
+	// MemoryBytes is the estimated size in bytes of the records sorted in memory at once, 64 MiB by default. It
+	// applies to codecs which implement Sizer, as the provided codecs do, and a chunk is spilled once either limit is
+	// reached. A single record larger than MemoryBytes is still sorted as a chunk of its own.
+	MemoryBytes int
+
+	// FanIn is the number of runs merged at once, 64 by default. More runs are merged in several passes.
+	FanIn int
+
+	// Dir is where the runs are spilled, the default directory for temporary files by default.
+	Dir string
+}
+
+type sorter[T any] struct {
+	codec   Codec[T]
+	less    func(a, b T) bool
+	opts    Options
+	dir     string
+	nextRun int
+}
+
+// Sort reads the records of r with codec, and writes them to w sorted by less. It is stable.
+//
+// Records are read into memory until opts.MemoryLimit or opts.MemoryBytes, sorted by merge.SortFunc and spilled to
+// a run in a temporary directory, which is removed before Sort returns. The runs are merged by merge.Merger, which
+// holds the next record of each run in a heap, up to opts.FanIn runs at once, so more runs are merged in passes of
+// consecutive runs. Input which fits in memory is never written to disk.
+func Sort[T any](r io.Reader, w io.Writer, codec Codec[T], less func(a, b T) bool, opts Options) error {
+	if opts.MemoryLimit <= 0 {
+		opts.MemoryLimit = defaultMemoryLimit
+	}
+
+	if opts.MemoryBytes <= 0 {
+		opts.MemoryBytes = defaultMemoryBytes
+	}
+
+	if opts.FanIn == 0 {
+		opts.FanIn = defaultFanIn
+	}
+
+	if opts.FanIn < 2 {
+		return invalidFanInError(opts.FanIn)
+	}
+
+	s := &sorter[T]{codec: codec, less: less, opts: opts}
+	defer s.cleanup()
+
+	return s.sort(r, w)
+}
+
+func (s *sorter[T]) sort(r io.Reader, w io.Writer) error {
+	dec := s.codec.NewDecoder(r)
+	chunk := make([]T, 0)
+	runs := make([]string, 0)
+	sizer, _ := s.codec.(Sizer[T])
+	size := 0
+
+	for {
+		item, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		itemSize := 0
+		if sizer != nil {
+			itemSize = sizer.Size(item)
+		}
+
+		if len(chunk) == s.opts.MemoryLimit || len(chunk) > 0 && size+itemSize > s.opts.MemoryBytes {
+			run, err := s.spill(chunk)
+			if err != nil {
+				return err
+			}
+
+			runs = append(runs, run)
+			chunk = chunk[:0]
+			size = 0
+		}
+
+		chunk = append(chunk, item)
+		size += itemSize
+	}
+
+	enc := s.codec.NewEncoder(w)
+	if len(runs) == 0 {
+		merge.SortFunc(chunk, s.less)
+		if err := encodeAll(enc, chunk); err != nil {
+			return err
+		}
+
+		return enc.Flush()
+	}
+
+	run, err := s.spill(chunk)
+	if err != nil {
+		return err
+	}
+
+	runs, err = s.mergePasses(append(runs, run))
+	if err != nil {
+		return err
+	}
+
+	if err := s.merge(runs, enc); err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+func (s *sorter[T]) spill(chunk []T) (string, error) {
+	merge.SortFunc(chunk, s.less)
+
+	return s.writeRun(func(enc Encoder[T]) error {
+		return encodeAll(enc, chunk)
+	})
+}
+
+// mergePasses merges groups of consecutive runs until at most opts.FanIn runs are left. Keeping the runs in input
+// order keeps the sort stable.
+func (s *sorter[T]) mergePasses(runs []string) ([]string, error) {
+	for len(runs) > s.opts.FanIn {
+		merged := make([]string, 0, (len(runs)+s.opts.FanIn-1)/s.opts.FanIn)
+
+		for lo := 0; lo < len(runs); lo += s.opts.FanIn {
+			hi := lo + s.opts.FanIn
+			if hi > len(runs) {
+				hi = len(runs)
+			}
+
+			group := runs[lo:hi]
+			if len(group) == 1 {
+				merged = append(merged, group[0])
+				continue
+			}
+
+			run, err := s.writeRun(func(enc Encoder[T]) error {
+				return s.merge(group, enc)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, path := range group {
+				if err := os.Remove(path); err != nil {
+					return nil, err
+				}
+			}
+
+			merged = append(merged, run)
+		}
+
+		runs = merged
+	}
+
+	return runs, nil
+}
+
+// merge writes the records of runs to enc in order through merge.Merger, equal records are taken from the earlier run
+// first.
+func (s *sorter[T]) merge(runs []string, enc Encoder[T]) error {
+	var decodeErr error
+	sources := make([]merge.Source[T], len(runs))
+
+	for i, path := range runs {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+		dec := s.codec.NewDecoder(f)
+		sources[i] = func() (T, bool) {
+			item, err := dec.Decode()
+			if err != nil && err != io.EOF && decodeErr == nil {
+				decodeErr = err
+			}
+
+			return item, err == nil
+		}
+	}
+
+	m := merge.NewMerger(s.less, merge.MergeOptions[T]{}, sources...)
+	for item, ok := m.Next(); ok && decodeErr == nil; item, ok = m.Next() {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+
+	return decodeErr
+}
+
+// writeRun creates a new run in the temporary directory and writes it with write.
+func (s *sorter[T]) writeRun(write func(enc Encoder[T]) error) (string, error) {
+	if s.dir == "" {
+		dir, err := os.MkdirTemp(s.opts.Dir, "external-sort-")
+		if err != nil {
+			return "", err
+		}
+
+		s.dir = dir
+	}
+
+	path := filepath.Join(s.dir, fmt.Sprintf("run-%d", s.nextRun))
+	s.nextRun++
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	enc := s.codec.NewEncoder(f)
+	if err := write(enc); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+
+	if err := enc.Flush(); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+
+	return path, f.Close()
+}
+
+func (s *sorter[T]) cleanup() {
+	if s.dir != "" {
+		_ = os.RemoveAll(s.dir)
+	}
+}
+
+func encodeAll[T any](enc Encoder[T], items []T) error {
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func invalidFanInError(fanIn int) error {
+	return fmt.Errorf(`fan-in must be at least 2, got %v`, fanIn)
+}
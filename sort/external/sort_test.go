@@ -0,0 +1,262 @@
+package external
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := map[string]struct {
+		records int
+		opts    Options
+		spilled bool
+	}{
+		"fits in memory": {
+			records: 100,
+			opts:    Options{},
+		},
+		"single merge pass": {
+			records: 1000,
+			opts:    Options{MemoryLimit: 100},
+			spilled: true,
+		},
+		"several merge passes": {
+			records: 1000,
+			opts:    Options{MemoryLimit: 10, FanIn: 3},
+			spilled: true,
+		},
+		"last run is shorter": {
+			records: 1001,
+			opts:    Options{MemoryLimit: 100, FanIn: 2},
+			spilled: true,
+		},
+		"spill by memory bytes": {
+			records: 1000,
+			opts:    Options{MemoryBytes: 1000},
+			spilled: true,
+		},
+		"records larger than memory bytes": {
+			records: 100,
+			opts:    Options{MemoryBytes: 1},
+			spilled: true,
+		},
+		"empty": {
+			records: 0,
+			opts:    Options{MemoryLimit: 10},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			lines := make([]string, tc.records)
+			for i := range lines {
+				lines[i] = strconv.Itoa(rand.Intn(tc.records))
+			}
+
+			input := strings.Join(lines, "\n")
+			sort.Strings(lines)
+			expected := ""
+			if len(lines) > 0 {
+				expected = strings.Join(lines, "\n") + "\n"
+			}
+
+			tc.opts.Dir = t.TempDir()
+			dir := &spyDir{path: tc.opts.Dir}
+
+			var out bytes.Buffer
+			err := Sort[string](strings.NewReader(input), &out, Lines{}, func(a, b string) bool {
+				dir.check()
+				return a < b
+			}, tc.opts)
+
+			a.NoError(err)
+			a.Equal(expected, out.String())
+			a.Equal(tc.spilled, dir.used)
+
+			entries, err := os.ReadDir(tc.opts.Dir)
+			a.NoError(err)
+			a.Empty(entries)
+		})
+	}
+}
+
+func TestSort_Stable(t *testing.T) {
+	records := make([][]string, 500)
+	for i := range records {
+		records[i] = []string{strconv.Itoa(rand.Intn(10)), strconv.Itoa(i)}
+	}
+
+	var input bytes.Buffer
+	enc := CSV{}.NewEncoder(&input)
+	assert.NoError(t, encodeAll(enc, records))
+	assert.NoError(t, enc.Flush())
+
+	expected := make([][]string, len(records))
+	copy(expected, records)
+	sort.SliceStable(expected, func(i, j int) bool {
+		return expected[i][0] < expected[j][0]
+	})
+
+	cases := map[string]struct {
+		opts Options
+	}{
+		"in memory": {
+			opts: Options{},
+		},
+		"several merge passes": {
+			opts: Options{MemoryLimit: 7, FanIn: 3},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			tc.opts.Dir = t.TempDir()
+
+			var out bytes.Buffer
+			err := Sort[[]string](bytes.NewReader(input.Bytes()), &out, CSV{}, func(a, b []string) bool {
+				return a[0] < b[0]
+			}, tc.opts)
+			a.NoError(err)
+
+			sorted, err := decodeAll[[]string](CSV{}, &out)
+			a.NoError(err)
+			a.Equal(expected, sorted)
+		})
+	}
+}
+
+func TestSort_LengthPrefixed(t *testing.T) {
+	a := assert.New(t)
+	records := make([][]byte, 300)
+	for i := range records {
+		records[i] = make([]byte, rand.Intn(20))
+		rand.Read(records[i])
+	}
+
+	var input bytes.Buffer
+	enc := LengthPrefixed{}.NewEncoder(&input)
+	a.NoError(encodeAll(enc, records))
+	a.NoError(enc.Flush())
+
+	var out bytes.Buffer
+	err := Sort[[]byte](&input, &out, LengthPrefixed{}, func(a, b []byte) bool {
+		return bytes.Compare(a, b) < 0
+	}, Options{MemoryLimit: 50, FanIn: 2, Dir: t.TempDir()})
+	a.NoError(err)
+
+	sorted, err := decodeAll[[]byte](LengthPrefixed{}, &out)
+	a.NoError(err)
+
+	sort.Slice(records, func(i, j int) bool {
+		return bytes.Compare(records[i], records[j]) < 0
+	})
+	a.Equal(records, sorted)
+}
+
+func TestSort_Errors(t *testing.T) {
+	cases := map[string]struct {
+		input    string
+		w        *failingWriter
+		opts     Options
+		expected string
+	}{
+		"invalid fan-in": {
+			input:    "b\na\n",
+			w:        &failingWriter{},
+			opts:     Options{FanIn: 1},
+			expected: `fan-in must be at least 2, got 1`,
+		},
+		"negative fan-in": {
+			input:    "b\na\n",
+			w:        &failingWriter{},
+			opts:     Options{FanIn: -1},
+			expected: `fan-in must be at least 2, got -1`,
+		},
+		"invalid record": {
+			input:    "b,1\na,\"1\n",
+			w:        &failingWriter{},
+			opts:     Options{MemoryLimit: 1},
+			expected: `parse error on line 2, column 6: extraneous or missing " in quoted-field`,
+		},
+		"write error": {
+			input:    "b\na\nc\n",
+			w:        &failingWriter{err: errors.New("disk full")},
+			opts:     Options{MemoryLimit: 1},
+			expected: `disk full`,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			tc.opts.Dir = t.TempDir()
+
+			err := Sort[[]string](strings.NewReader(tc.input), tc.w, CSV{}, func(a, b []string) bool {
+				return a[0] < b[0]
+			}, tc.opts)
+			a.EqualError(err, tc.expected)
+
+			entries, err := os.ReadDir(tc.opts.Dir)
+			a.NoError(err)
+			a.Empty(entries)
+		})
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	lines := make([]string, 100000)
+	for i := range lines {
+		lines[i] = strconv.Itoa(rand.Int())
+	}
+
+	input := strings.Join(lines, "\n")
+	less := func(a, b string) bool {
+		return a < b
+	}
+
+	for _, limit := range []int{100000, 10000, 1000} {
+		b.Run("memory limit "+strconv.Itoa(limit), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var out bytes.Buffer
+				_ = Sort[string](strings.NewReader(input), &out, Lines{}, less, Options{MemoryLimit: limit})
+			}
+		})
+	}
+}
+
+// spyDir records whether a run was spilled to path by the time the records are compared.
+type spyDir struct {
+	path string
+	used bool
+}
+
+func (d *spyDir) check() {
+	if d.used {
+		return
+	}
+
+	if entries, _ := os.ReadDir(d.path); len(entries) > 0 {
+		d.used = true
+	}
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,57 @@
+package heap
+
+import (
+	"fmt"
+	dheap "github.com/CameronXie/algorithms-go/tree/heap"
+	"golang.org/x/exp/constraints"
+	"sort"
+)
+
+const defaultArity = 2
+
+// Sort is an in-place heapsort on a binary max heap, it takes O(n log n) time and O(1) extra space. It is not stable.
+func Sort(data sort.Interface) {
+	SortD(data, defaultArity)
+}
+
+// SortD is Sort on a d-ary max heap, a larger d gives a shallower heap with more comparisons per level.
+func SortD(data sort.Interface, d int) {
+	heapSort(data.Len(), d, func(i, j int) bool {
+		return data.Less(j, i)
+	}, data.Swap)
+}
+
+func SortFunc[T any](s []T, less func(a, b T) bool) {
+	SortFuncD(s, defaultArity, less)
+}
+
+func SortFuncD[T any](s []T, d int, less func(a, b T) bool) {
+	heapSort(len(s), d, func(i, j int) bool {
+		return less(s[j], s[i])
+	}, func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
+
+func SortOrdered[T constraints.Ordered](s []T) {
+	SortFunc(s, func(a, b T) bool {
+		return a < b
+	})
+}
+
+// heapSort builds a heap with the largest item at the top, above(i, j) reports whether i belongs above j, then moves
+// the top to the end of the shrinking heap.
+func heapSort(n, d int, above func(i, j int) bool, swap func(i, j int)) {
+	if d < 2 {
+		panic(fmt.Errorf(`d must be at least 2, got %v`, d))
+	}
+
+	for i := (n - 2) / d; i >= 0; i-- {
+		dheap.SiftDown(d, i, n, above, swap)
+	}
+
+	for end := n - 1; end > 0; end-- {
+		swap(0, end)
+		dheap.SiftDown(d, 0, end, above, swap)
+	}
+}
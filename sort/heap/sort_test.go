@@ -0,0 +1,204 @@
+package heap
+
+import (
+	"fmt"
+	"github.com/CameronXie/algorithms-go/sort/internal/sorttest"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := map[string]struct {
+		input    testData
+		expected testData
+	}{
+		"sort descending list": {
+			input:    testData{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			expected: testData{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		"sort random list": {
+			input:    testData{6, 2, 7, 1, 9, 10, 8, 3, 5, 4},
+			expected: testData{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		"sort duplicated items": {
+			input:    testData{3, 1, 3, 2, 1, 3, 2},
+			expected: testData{1, 1, 2, 2, 3, 3, 3},
+		},
+		"sort empty list": {
+			input:    testData{},
+			expected: testData{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			Sort(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortD(t *testing.T) {
+	cases := map[string]struct {
+		d    int
+		size int
+	}{
+		"sort with binary heap": {
+			d:    2,
+			size: 1000,
+		},
+		"sort with ternary heap": {
+			d:    3,
+			size: 1001,
+		},
+		"sort with 4-ary heap": {
+			d:    4,
+			size: 1002,
+		},
+		"sort with 8-ary heap": {
+			d:    8,
+			size: 7,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			input := rand.Perm(tc.size)
+			expected := make([]int, tc.size)
+			copy(expected, input)
+			sort.Ints(expected)
+
+			data := make(testData, tc.size)
+			copy(data, input)
+			SortD(data, tc.d)
+			a.Equal(testData(expected), data)
+
+			SortFuncD(input, tc.d, func(a, b int) bool {
+				return a < b
+			})
+			a.Equal(expected, input)
+		})
+	}
+}
+
+func TestSortD_InvalidArity(t *testing.T) {
+	assert.PanicsWithError(t, "d must be at least 2, got 1", func() {
+		SortD(testData{2, 1}, 1)
+	})
+}
+
+func TestSortFunc(t *testing.T) {
+	cases := map[string]struct {
+		input    []testItem
+		expected []testItem
+	}{
+		"sort by key": {
+			input: []testItem{
+				{key: 3, value: "C"},
+				{key: 1, value: "A"},
+				{key: 4, value: "D"},
+				{key: 2, value: "B"},
+			},
+			expected: []testItem{
+				{key: 1, value: "A"},
+				{key: 2, value: "B"},
+				{key: 3, value: "C"},
+				{key: 4, value: "D"},
+			},
+		},
+		"sort empty list": {
+			input:    []testItem{},
+			expected: []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortFunc(tc.input, func(a, b testItem) bool {
+				return a.key < b.key
+			})
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortOrdered(t *testing.T) {
+	cases := map[string]struct {
+		input    []string
+		expected []string
+	}{
+		"sort strings": {
+			input:    []string{"pear", "apple", "fig", "banana", "apple"},
+			expected: []string{"apple", "apple", "banana", "fig", "pear"},
+		},
+		"sort single item": {
+			input:    []string{"fig"},
+			expected: []string{"fig"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortOrdered(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func BenchmarkSortFuncD(b *testing.B) {
+	input := rand.Perm(1 << 20)
+	data := make([]int, len(input))
+
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("%v-ary", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(data, input)
+				SortFuncD(data, d, func(a, b int) bool {
+					return a < b
+				})
+			}
+		})
+	}
+}
+
+type testData []int
+
+func (d testData) Len() int {
+	return len(d)
+}
+
+func (d testData) Less(i, j int) bool {
+	return d[i] < d[j]
+}
+
+func (d testData) Swap(i, j int) {
+	d[i], d[j] = d[j], d[i]
+}
+
+type testItem struct {
+	key   int
+	value string
+}
+
+func TestSort_Stability(t *testing.T) {
+	t.Run("Sort", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			Sort(sorttest.Data(items))
+		}, false)
+	})
+
+	t.Run("SortFuncD", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			SortFuncD(items, 4, sorttest.Less)
+		}, false)
+	})
+}
@@ -1,5 +1,9 @@
 package insertion
 
+import (
+	"golang.org/x/exp/constraints"
+)
+
 type Interface interface {
 	Len() int
 	Less(idx int, value any) bool
@@ -7,19 +11,43 @@ type Interface interface {
 	Set(idx int, value any)
 }
 
+// Sort is stable. The item at i is inserted after the sorted items which are not greater, so Less(i, Get(j)) finds
+// its position before anything is shifted.
 func Sort(data Interface) {
 	n := data.Len()
 	for i := 1; i < n; i++ {
-		idx, pivot := i, data.Get(i)
-		for j := i - 1; j >= 0; j-- {
-			if data.Less(j, pivot) {
-				break
-			}
-
-			data.Set(j+1, data.Get(j))
-			idx = j
+		idx := i
+		for idx > 0 && data.Less(i, data.Get(idx-1)) {
+			idx--
+		}
+
+		if idx == i {
+			continue
+		}
+
+		pivot := data.Get(i)
+		for j := i; j > idx; j-- {
+			data.Set(j, data.Get(j-1))
 		}
 
 		data.Set(idx, pivot)
 	}
 }
+
+// SortFunc is stable like Sort.
+func SortFunc[T any](s []T, less func(a, b T) bool) {
+	for i := 1; i < len(s); i++ {
+		pivot, j := s[i], i
+		for ; j > 0 && less(pivot, s[j-1]); j-- {
+			s[j] = s[j-1]
+		}
+
+		s[j] = pivot
+	}
+}
+
+func SortOrdered[T constraints.Ordered](s []T) {
+	SortFunc(s, func(a, b T) bool {
+		return a < b
+	})
+}
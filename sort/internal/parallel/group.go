@@ -0,0 +1,42 @@
+package parallel
+
+import (
+	"runtime"
+)
+
+// Group runs pairs of tasks on at most workers goroutines, including the caller's. A task runs on the caller's
+// goroutine when every worker is busy, so nested calls never wait for a free worker and cannot deadlock.
+type Group struct {
+	sem chan struct{}
+}
+
+// Do runs f and h and returns when both are done, f runs on another goroutine when a worker is free.
+func (g *Group) Do(f, h func()) {
+	select {
+	case g.sem <- struct{}{}:
+		done := make(chan struct{})
+		go func() {
+			defer func() {
+				<-g.sem
+				close(done)
+			}()
+
+			f()
+		}()
+
+		h()
+		<-done
+	default:
+		f()
+		h()
+	}
+}
+
+// New creates a Group of workers goroutines, it defaults to GOMAXPROCS when workers is not positive.
+func New(workers int) *Group {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return &Group{sem: make(chan struct{}, workers-1)}
+}
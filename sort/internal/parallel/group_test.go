@@ -0,0 +1,66 @@
+package parallel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_Do(t *testing.T) {
+	cases := map[string]struct {
+		workers int
+	}{
+		"single worker runs sequentially": {
+			workers: 1,
+		},
+		"at most 4 workers": {
+			workers: 4,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			g := New(tc.workers)
+
+			var running, peak int32
+			var mu sync.Mutex
+			task := func() {
+				r := atomic.AddInt32(&running, 1)
+				mu.Lock()
+				if r > peak {
+					peak = r
+				}
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			}
+
+			var tree func(depth int)
+			tree = func(depth int) {
+				if depth == 0 {
+					task()
+					return
+				}
+
+				g.Do(func() {
+					tree(depth - 1)
+				}, func() {
+					tree(depth - 1)
+				})
+			}
+
+			tree(6)
+			a.LessOrEqual(peak, int32(tc.workers))
+			a.Equal(int32(0), running)
+		})
+	}
+}
+
+func TestNew_DefaultWorkers(t *testing.T) {
+	assert.Equal(t, runtime.GOMAXPROCS(0)-1, cap(New(0).sem))
+}
@@ -0,0 +1,15 @@
+package sortkey
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Integer maps k to an uint64 in the same order, by flipping the sign bit of signed integers after sign extension.
+func Integer[K constraints.Integer](k K) uint64 {
+	var zero K
+	if ^zero < 0 {
+		return uint64(k) ^ 1<<63
+	}
+
+	return uint64(k)
+}
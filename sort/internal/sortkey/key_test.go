@@ -0,0 +1,32 @@
+package sortkey
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestInteger(t *testing.T) {
+	cases := map[string]struct {
+		keys []uint64
+	}{
+		"signed integers": {
+			keys: []uint64{Integer(math.MinInt64), Integer(-1), Integer(0), Integer(1), Integer(math.MaxInt64)},
+		},
+		"small signed integers": {
+			keys: []uint64{Integer(int8(-128)), Integer(int8(-1)), Integer(int8(0)), Integer(int8(127))},
+		},
+		"unsigned integers": {
+			keys: []uint64{Integer(uint8(0)), Integer(uint8(255)), Integer(uint64(math.MaxUint64))},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			for i := 1; i < len(tc.keys); i++ {
+				a.Less(tc.keys[i-1], tc.keys[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package sorttest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Item is a key tagged with its position in the input, so equal keys which were reordered can be told apart.
+type Item struct {
+	Key int
+	Tag int
+}
+
+func Less(a, b Item) bool {
+	return a.Key < b.Key
+}
+
+// Data sorts items by key through sort.Interface.
+type Data []Item
+
+func (d Data) Len() int {
+	return len(d)
+}
+
+func (d Data) Less(i, j int) bool {
+	return d[i].Key < d[j].Key
+}
+
+func (d Data) Swap(i, j int) {
+	d[i], d[j] = d[j], d[i]
+}
+
+type pattern func(r *rand.Rand, i, n int) int
+
+var patterns = map[string]pattern{
+	"few distinct keys": func(r *rand.Rand, _, _ int) int {
+		return r.Intn(4)
+	},
+	"many duplicate keys": func(r *rand.Rand, _, n int) int {
+		return r.Intn(n/8 + 1)
+	},
+	"all equal": func(*rand.Rand, int, int) int {
+		return 0
+	},
+	"sorted steps": func(_ *rand.Rand, i, _ int) int {
+		return i / 3
+	},
+	"reversed steps": func(_ *rand.Rand, i, n int) int {
+		return (n - i) / 3
+	},
+	"negative keys": func(r *rand.Rand, _, _ int) int {
+		return r.Intn(7) - 3
+	},
+}
+
+var sizes = []int{0, 1, 2, 7, 13, 64, 100, 1000}
+
+// Check sorts inputs of every pattern and size with sortItems, and checks that they are sorted by key. When stable is
+// true, equal keys must keep their input order. Otherwise sortItems must reorder equal keys of at least one input,
+// which confirms that it is documented as not stable for a reason.
+func Check(t *testing.T, sortItems func(items []Item), stable bool) {
+	unstable := false
+
+	for name, p := range patterns {
+		for _, size := range sizes {
+			t.Run(name, func(t *testing.T) {
+				a := assert.New(t)
+				items := generate(p, size)
+
+				sortItems(items)
+
+				a.True(sort.SliceIsSorted(items, func(i, j int) bool {
+					return Less(items[i], items[j])
+				}), "not sorted: %v", items)
+				a.True(isPermutation(items), "not a permutation of the input: %v", items)
+
+				if !IsStable(items) {
+					unstable = true
+					a.False(stable, "equal keys reordered: %v", items)
+				}
+			})
+		}
+	}
+
+	if !stable {
+		assert.True(t, unstable, "every input was sorted stably")
+	}
+}
+
+// IsStable reports whether items with equal keys are in the order of their tags.
+func IsStable(items []Item) bool {
+	for i := 1; i < len(items); i++ {
+		if items[i].Key == items[i-1].Key && items[i].Tag < items[i-1].Tag {
+			return false
+		}
+	}
+
+	return true
+}
+
+// generate returns size items of pattern, tagged by their position. The same input is returned every time.
+func generate(p pattern, size int) []Item {
+	r := rand.New(rand.NewSource(int64(size)))
+	items := make([]Item, size)
+	for i := range items {
+		items[i] = Item{Key: p(r, i, size), Tag: i}
+	}
+
+	return items
+}
+
+func isPermutation(items []Item) bool {
+	seen := make([]bool, len(items))
+	for _, item := range items {
+		if item.Tag < 0 || item.Tag >= len(items) || seen[item.Tag] {
+			return false
+		}
+
+		seen[item.Tag] = true
+	}
+
+	return true
+}
@@ -0,0 +1,55 @@
+package sorttest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("stable sort", func(t *testing.T) {
+		Check(t, func(items []Item) {
+			sort.Stable(Data(items))
+		}, true)
+	})
+
+	t.Run("unstable sort", func(t *testing.T) {
+		Check(t, func(items []Item) {
+			sort.Sort(Data(items))
+		}, false)
+	})
+}
+
+func TestIsStable(t *testing.T) {
+	cases := map[string]struct {
+		items    []Item
+		expected bool
+	}{
+		"equal keys in order": {
+			items:    []Item{{Key: 1, Tag: 2}, {Key: 2, Tag: 0}, {Key: 2, Tag: 1}},
+			expected: true,
+		},
+		"equal keys reordered": {
+			items:    []Item{{Key: 1, Tag: 2}, {Key: 2, Tag: 1}, {Key: 2, Tag: 0}},
+			expected: false,
+		},
+		"empty": {
+			items:    []Item{},
+			expected: true,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsStable(tc.items))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	a := assert.New(t)
+	items := generate(patterns["few distinct keys"], 100)
+
+	a.Equal(items, generate(patterns["few distinct keys"], 100))
+	a.True(isPermutation(items))
+}
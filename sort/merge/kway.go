@@ -0,0 +1,150 @@
+package merge
+
+import (
+	"github.com/CameronXie/algorithms-go/tree/heap"
+)
+
+// Source returns the items of a sorted input one by one, and false once the input is exhausted. Any Next method of
+// the same signature, such as heap.Iterator.Next or Merger.Next, is a Source.
+type Source[T any] func() (T, bool)
+
+func FromSlice[T any](s []T) Source[T] {
+	return func() (T, bool) {
+		if len(s) == 0 {
+			var empty T
+			return empty, false
+		}
+
+		item := s[0]
+		s = s[1:]
+
+		return item, true
+	}
+}
+
+// FromChan returns a Source receiving from ch until ch is closed.
+func FromChan[T any](ch <-chan T) Source[T] {
+	return func() (T, bool) {
+		item, ok := <-ch
+		return item, ok
+	}
+}
+
+type MergeOptions[T any] struct {
+	// Dedup returns only the first of equal items.
+	Dedup bool
+
+	// Combine folds equal items into one, it is called with the items combined so far and the next equal item. It
+	// overrides Dedup.
+	Combine func(acc, item T) T
+}
+
+type cursor[T any] struct {
+	source int
+	item   T
+}
+
+// Merger merges sorted sources into one sorted stream. A heap holds the next item of every source, so merging n items
+// from k sources takes O(n log k). A source is only read for its next item on the call of Next after its previous
+// item was returned, so a source such as a channel never blocks the return of an item already received. When
+// combining, the sources of equal items are read before returning, as the next items decide what is combined. Equal
+// items are returned in the order of their sources.
+type Merger[T any] struct {
+	sources []Source[T]
+	less    func(a, b T) bool
+	combine func(acc, item T) T
+	heads   *heap.FuncHeap[cursor[T], int]
+
+	// returned is true when the top of heads was returned, and its source is yet to be read.
+	returned bool
+}
+
+// Next returns the next item, and false once all sources are exhausted.
+func (m *Merger[T]) Next() (T, bool) {
+	if m.heads == nil {
+		m.init()
+	}
+
+	if m.returned {
+		m.returned = false
+		m.advance()
+	}
+
+	top, ok := m.heads.Peek()
+	if !ok || m.combine == nil {
+		m.returned = ok
+		return top.item, ok
+	}
+
+	item := top.item
+	m.advance()
+	for top, ok := m.heads.Peek(); ok && !m.less(item, top.item); top, ok = m.heads.Peek() {
+		item = m.combine(item, top.item)
+		m.advance()
+	}
+
+	return item, true
+}
+
+func (m *Merger[T]) init() {
+	heads := make([]cursor[T], 0, len(m.sources))
+	for i, source := range m.sources {
+		if item, ok := source(); ok {
+			heads = append(heads, cursor[T]{source: i, item: item})
+		}
+	}
+
+	m.heads = heap.NewFunc(2, heads, func(c cursor[T]) int {
+		return c.source
+	}, func(a, b cursor[T]) bool {
+		if m.less(a.item, b.item) {
+			return true
+		}
+
+		return !m.less(b.item, a.item) && a.source < b.source
+	})
+}
+
+// advance replaces the top item with the next item of its source, or removes it when the source is exhausted.
+func (m *Merger[T]) advance() {
+	top, _ := m.heads.Peek()
+	next, ok := m.sources[top.source]()
+	if !ok {
+		m.heads.Pop()
+		return
+	}
+
+	_ = m.heads.Update(top.source, func(c cursor[T]) cursor[T] {
+		return cursor[T]{source: c.source, item: next}
+	})
+}
+
+// NewMerger creates a Merger of sources sorted by less. The sources are first read on the first call of Next.
+func NewMerger[T any](less func(a, b T) bool, opts MergeOptions[T], sources ...Source[T]) *Merger[T] {
+	combine := opts.Combine
+	if combine == nil && opts.Dedup {
+		combine = func(acc, _ T) T {
+			return acc
+		}
+	}
+
+	return &Merger[T]{sources: sources, less: less, combine: combine}
+}
+
+// MergeSlices merges the sorted slices into a new sorted slice.
+func MergeSlices[T any](less func(a, b T) bool, slices ...[]T) []T {
+	n := 0
+	sources := make([]Source[T], len(slices))
+	for i, s := range slices {
+		n += len(s)
+		sources[i] = FromSlice(s)
+	}
+
+	merged := make([]T, 0, n)
+	m := NewMerger(less, MergeOptions[T]{}, sources...)
+	for item, ok := m.Next(); ok; item, ok = m.Next() {
+		merged = append(merged, item)
+	}
+
+	return merged
+}
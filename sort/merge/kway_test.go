@@ -0,0 +1,204 @@
+package merge
+
+import (
+	"github.com/CameronXie/algorithms-go/tree/heap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMerger(t *testing.T) {
+	cases := map[string]struct {
+		sources  [][]testItem
+		opts     MergeOptions[testItem]
+		expected []testItem
+	}{
+		"merge sources": {
+			sources: [][]testItem{
+				{{key: 1, value: "a"}, {key: 4, value: "a"}, {key: 7, value: "a"}},
+				{{key: 2, value: "b"}, {key: 5, value: "b"}},
+				{{key: 3, value: "c"}, {key: 6, value: "c"}, {key: 8, value: "c"}, {key: 9, value: "c"}},
+			},
+			expected: []testItem{
+				{key: 1, value: "a"}, {key: 2, value: "b"}, {key: 3, value: "c"},
+				{key: 4, value: "a"}, {key: 5, value: "b"}, {key: 6, value: "c"},
+				{key: 7, value: "a"}, {key: 8, value: "c"}, {key: 9, value: "c"},
+			},
+		},
+		"equal items in source order": {
+			sources: [][]testItem{
+				{{key: 1, value: "a"}, {key: 2, value: "a"}},
+				{{key: 1, value: "b"}, {key: 1, value: "b2"}},
+				{{key: 1, value: "c"}},
+			},
+			expected: []testItem{
+				{key: 1, value: "a"}, {key: 1, value: "b"}, {key: 1, value: "b2"}, {key: 1, value: "c"},
+				{key: 2, value: "a"},
+			},
+		},
+		"dedup": {
+			sources: [][]testItem{
+				{{key: 1, value: "a"}, {key: 2, value: "a"}},
+				{{key: 1, value: "b"}, {key: 1, value: "b2"}, {key: 3, value: "b"}},
+				{{key: 3, value: "c"}},
+			},
+			opts: MergeOptions[testItem]{Dedup: true},
+			expected: []testItem{
+				{key: 1, value: "a"}, {key: 2, value: "a"}, {key: 3, value: "b"},
+			},
+		},
+		"combine": {
+			sources: [][]testItem{
+				{{key: 1, value: "a"}, {key: 2, value: "a"}},
+				{{key: 1, value: "b"}, {key: 3, value: "b"}},
+				{{key: 1, value: "c"}, {key: 3, value: "c"}},
+			},
+			opts: MergeOptions[testItem]{
+				Dedup: true,
+				Combine: func(acc, item testItem) testItem {
+					return testItem{key: acc.key, value: acc.value + item.value}
+				},
+			},
+			expected: []testItem{
+				{key: 1, value: "abc"}, {key: 2, value: "a"}, {key: 3, value: "bc"},
+			},
+		},
+		"empty sources": {
+			sources: [][]testItem{
+				{},
+				{{key: 1, value: "b"}},
+				{},
+			},
+			expected: []testItem{{key: 1, value: "b"}},
+		},
+		"no sources": {
+			sources:  [][]testItem{},
+			expected: []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			sources := make([]Source[testItem], len(tc.sources))
+			for i, s := range tc.sources {
+				sources[i] = FromSlice(s)
+			}
+
+			m := NewMerger(func(a, b testItem) bool {
+				return a.key < b.key
+			}, tc.opts, sources...)
+
+			merged := make([]testItem, 0)
+			for item, ok := m.Next(); ok; item, ok = m.Next() {
+				merged = append(merged, item)
+			}
+
+			a.Equal(tc.expected, merged)
+
+			_, ok := m.Next()
+			a.False(ok)
+		})
+	}
+}
+
+func TestMerger_Lazy(t *testing.T) {
+	a := assert.New(t)
+	reads := make([]int, 3)
+	sources := make([]Source[int], 3)
+	for i := range sources {
+		i := i
+		next := FromSlice([]int{i, i + 3, i + 6})
+		sources[i] = func() (int, bool) {
+			reads[i]++
+			return next()
+		}
+	}
+
+	m := NewMerger(intLess, MergeOptions[int]{}, sources...)
+	a.Equal([]int{0, 0, 0}, reads)
+
+	item, _ := m.Next()
+	a.Equal(0, item)
+	a.Equal([]int{1, 1, 1}, reads)
+
+	item, _ = m.Next()
+	a.Equal(1, item)
+	a.Equal([]int{2, 1, 1}, reads)
+}
+
+func TestMerger_ChanNotBlocking(t *testing.T) {
+	a := assert.New(t)
+	ch := make(chan int, 1)
+	ch <- 1
+
+	m := NewMerger(intLess, MergeOptions[int]{}, FromChan(ch))
+	items := make(chan int)
+	go func() {
+		item, _ := m.Next()
+		items <- item
+	}()
+
+	select {
+	case item := <-items:
+		a.Equal(1, item)
+	case <-time.After(time.Second):
+		a.Fail("Next blocked on the channel after receiving an item")
+	}
+
+	close(ch)
+	_, ok := m.Next()
+	a.False(ok)
+}
+
+func TestMerger_Sources(t *testing.T) {
+	a := assert.New(t)
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, i := range []int{2, 5, 8} {
+			ch <- i
+		}
+	}()
+
+	it := heap.NewFunc(2, []int{7, 1, 4}, func(i int) int {
+		return i
+	}, intLess).Iterator()
+
+	nested := NewMerger(intLess, MergeOptions[int]{}, FromSlice([]int{0, 3}), FromSlice([]int{6, 9}))
+
+	m := NewMerger(intLess, MergeOptions[int]{}, FromChan(ch), it.Next, nested.Next)
+
+	merged := make([]int, 0)
+	for item, ok := m.Next(); ok; item, ok = m.Next() {
+		merged = append(merged, item)
+	}
+
+	a.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, merged)
+}
+
+func TestMergeSlices(t *testing.T) {
+	cases := map[string]struct {
+		slices   [][]int
+		expected []int
+	}{
+		"merge slices": {
+			slices:   [][]int{{1, 4, 9}, {2, 3}, {}, {5, 6, 7, 8}},
+			expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		"no slices": {
+			slices:   [][]int{},
+			expected: []int{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MergeSlices(intLess, tc.slices...))
+		})
+	}
+}
+
+func intLess(a, b int) bool {
+	return a < b
+}
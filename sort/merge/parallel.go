@@ -0,0 +1,183 @@
+package merge
+
+import (
+	"context"
+	"github.com/CameronXie/algorithms-go/sort/internal/parallel"
+)
+
+const defaultParallelThreshold = 1 << 13
+
+// Options configures the parallel sorts.
+type Options struct {
+	// Workers is the number of goroutines sorting at the same time, including the caller's, GOMAXPROCS by default.
+	Workers int
+
+	// Threshold is the length up to which a range is sorted or merged on a single goroutine, 8192 by default.
+	Threshold int
+}
+
+// ParallelSort returns a sorted copy of data like Sort, sorting on up to opts.Workers goroutines. It returns the
+// error of ctx when ctx is done before data is sorted.
+func ParallelSort[T Interface](ctx context.Context, data []T, opts Options) ([]T, error) {
+	copied := make([]T, len(data))
+	copy(copied, data)
+
+	err := ParallelSortFunc(ctx, copied, func(a, b T) bool {
+		return a.Less(b)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return copied, nil
+}
+
+// ParallelSortFunc sorts s in place like SortFunc, it is stable. The halves of a range are sorted on separate
+// goroutines and merged by splitting the merge into halves as well, so every level of the merge is parallel. Ranges up
+// to opts.Threshold are sorted by Timsort. It returns the error of ctx when ctx is done before s is sorted, s is then
+// left in an unspecified order.
+func ParallelSortFunc[T any](ctx context.Context, s []T, less func(a, b T) bool, opts Options) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if opts.Threshold <= 0 {
+		opts.Threshold = defaultParallelThreshold
+	}
+
+	p := &parallelSort[T]{
+		ctx:       ctx,
+		less:      less,
+		threshold: opts.Threshold,
+		group:     parallel.New(opts.Workers),
+	}
+
+	if len(s) <= p.threshold {
+		timsort(s, less)
+		return ctx.Err()
+	}
+
+	p.sort(s, make([]T, len(s)), false)
+	return ctx.Err()
+}
+
+type parallelSort[T any] struct {
+	ctx       context.Context
+	less      func(a, b T) bool
+	threshold int
+	group     *parallel.Group
+}
+
+// sort sorts s and leaves the result in buf when toBuf, otherwise in s. The halves are sorted into the other slice
+// and merged back, so no level copies its result.
+func (p *parallelSort[T]) sort(s, buf []T, toBuf bool) {
+	if len(s) <= p.threshold {
+		if p.ctx.Err() == nil {
+			timsort(s, p.less)
+		}
+
+		if toBuf {
+			copy(buf, s)
+		}
+
+		return
+	}
+
+	mid := len(s) / 2
+	p.group.Do(func() {
+		p.sort(s[:mid], buf[:mid], !toBuf)
+	}, func() {
+		p.sort(s[mid:], buf[mid:], !toBuf)
+	})
+
+	src, dst := buf, s
+	if toBuf {
+		src, dst = s, buf
+	}
+
+	p.merge(src[:mid], src[mid:], dst)
+}
+
+// merge merges the sorted a and b into dst. The larger of a and b is split at its middle item, and the other at the
+// position of that item, so both halves of dst can be merged independently. A split which leaves one half empty makes
+// no progress, the range is then merged on a single goroutine.
+func (p *parallelSort[T]) merge(a, b, dst []T) {
+	if p.ctx.Err() != nil {
+		copy(dst, a)
+		copy(dst[len(a):], b)
+		return
+	}
+
+	if len(a)+len(b) <= p.threshold {
+		mergeInto(a, b, dst, p.less)
+		return
+	}
+
+	var i, j int
+	if len(a) >= len(b) {
+		i = len(a) / 2
+		j = searchLeft(b, a[i], p.less)
+	} else {
+		j = len(b) / 2
+		i = searchRight(a, b[j], p.less)
+	}
+
+	if i+j == 0 || i+j == len(a)+len(b) {
+		mergeInto(a, b, dst, p.less)
+		return
+	}
+
+	p.group.Do(func() {
+		p.merge(a[:i], b[:j], dst[:i+j])
+	}, func() {
+		p.merge(a[i:], b[j:], dst[i+j:])
+	})
+}
+
+// mergeInto merges the sorted a and b into dst, taking the item of a on ties.
+func mergeInto[T any](a, b, dst []T, less func(a, b T) bool) {
+	i, j, k := 0, 0, 0
+	for ; i < len(a) && j < len(b); k++ {
+		if less(b[j], a[i]) {
+			dst[k] = b[j]
+			j++
+			continue
+		}
+
+		dst[k] = a[i]
+		i++
+	}
+
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// searchLeft returns the index of the first item in the sorted s which is not less than key.
+func searchLeft[T any](s []T, key T, less func(a, b T) bool) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if less(s[mid], key) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo
+}
+
+// searchRight returns the index of the first item in the sorted s which is greater than key.
+func searchRight[T any](s []T, key T, less func(a, b T) bool) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if less(key, s[mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return lo
+}
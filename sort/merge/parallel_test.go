@@ -0,0 +1,164 @@
+package merge
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestParallelSortFunc(t *testing.T) {
+	cases := map[string]struct {
+		input []int
+		opts  Options
+	}{
+		"random": {
+			input: rand.Perm(10000),
+			opts:  Options{Workers: 4, Threshold: 64},
+		},
+		"few distinct values": {
+			input: func() []int {
+				data := make([]int, 10000)
+				for i := range data {
+					data[i] = rand.Intn(4)
+				}
+
+				return data
+			}(),
+			opts: Options{Workers: 4, Threshold: 64},
+		},
+		"sorted batches": {
+			input: batches(10000, 7),
+			opts:  Options{Workers: 3, Threshold: 100},
+		},
+		"single worker": {
+			input: rand.Perm(5000),
+			opts:  Options{Workers: 1, Threshold: 64},
+		},
+		"default options": {
+			input: rand.Perm(20000),
+		},
+		"threshold of 1": {
+			input: rand.Perm(1000),
+			opts:  Options{Workers: 4, Threshold: 1},
+		},
+		"threshold of 1 with duplicates": {
+			input: []int{1, 1, 2, 2, 1, 1, 2, 2},
+			opts:  Options{Workers: 2, Threshold: 1},
+		},
+		"shorter than threshold": {
+			input: rand.Perm(100),
+			opts:  Options{Workers: 4, Threshold: 1000},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			items := make([]testItem, len(tc.input))
+			for i, key := range tc.input {
+				items[i] = testItem{key: key, value: string(rune('a' + i%26))}
+			}
+
+			expected := make([]testItem, len(items))
+			copy(expected, items)
+			sort.SliceStable(expected, func(i, j int) bool {
+				return expected[i].key < expected[j].key
+			})
+
+			err := ParallelSortFunc(context.Background(), items, func(a, b testItem) bool {
+				return a.key < b.key
+			}, tc.opts)
+
+			a.NoError(err)
+			a.Equal(expected, items)
+		})
+	}
+}
+
+func TestParallelSort(t *testing.T) {
+	a := assert.New(t)
+	input := make([]sortableInt, 5000)
+	for i := range input {
+		input[i] = sortableInt(rand.Intn(1000))
+	}
+
+	original := make([]sortableInt, len(input))
+	copy(original, input)
+
+	sorted, err := ParallelSort(context.Background(), input, Options{Workers: 4, Threshold: 32})
+
+	a.NoError(err)
+	a.True(sort.SliceIsSorted(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	}))
+	a.Equal(original, input)
+}
+
+func TestParallelSortFunc_Cancel(t *testing.T) {
+	cases := map[string]struct {
+		cancelAfter int
+	}{
+		"cancelled before sorting": {
+			cancelAfter: 0,
+		},
+		"cancelled while sorting": {
+			cancelAfter: 5000,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tc.cancelAfter == 0 {
+				cancel()
+			}
+
+			input := rand.Perm(100000)
+			data := make([]int, len(input))
+			copy(data, input)
+
+			compares := make(chan struct{}, tc.cancelAfter)
+			err := ParallelSortFunc(ctx, data, func(a, b int) bool {
+				select {
+				case compares <- struct{}{}:
+				default:
+					cancel()
+				}
+
+				return a < b
+			}, Options{Workers: 4, Threshold: 256})
+
+			a.ErrorIs(err, context.Canceled)
+			sort.Ints(input)
+			sort.Ints(data)
+			a.Equal(input, data)
+		})
+	}
+}
+
+func BenchmarkParallelSortFunc(b *testing.B) {
+	input := rand.Perm(1 << 20)
+	data := make([]int, len(input))
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	b.Run("timsort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			SortFunc(data, less)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			_ = ParallelSortFunc(context.Background(), data, less, Options{})
+		}
+	})
+}
@@ -1,40 +1,162 @@
 package merge
 
+import (
+	"golang.org/x/exp/constraints"
+	"sort"
+)
+
+const stableBlockSize = 20
+
 type Interface interface {
 	Less(i any) bool
 }
 
+// Sort returns a sorted copy of data, it is a stable Timsort.
 func Sort[T Interface](data []T) []T {
 	copied := make([]T, len(data))
 	copy(copied, data)
 
-	return divide(copied)
+	timsort(copied, func(a, b T) bool {
+		return a.Less(b)
+	})
+
+	return copied
 }
 
-func divide[T Interface](data []T) []T {
-	n := len(data)
-	if n <= 1 {
-		return data
+// SortFunc sorts s in place, it is a stable Timsort which takes O(n) on data made of a few sorted runs.
+func SortFunc[T any](s []T, less func(a, b T) bool) {
+	timsort(s, less)
+}
+
+func SortOrdered[T constraints.Ordered](s []T) {
+	SortFunc(s, func(a, b T) bool {
+		return a < b
+	})
+}
+
+// Stable sorts data in place and keeps equal items in their order, for data which only provides sort.Interface. It
+// sorts blocks of 20 items by insertion sort and merges them with SymMerge, which rotates instead of using a buffer,
+// so it takes O(n log n) comparisons and O(n log² n) swaps.
+func Stable(data sort.Interface) {
+	n := data.Len()
+	blockSize := stableBlockSize
+
+	lo := 0
+	for ; lo+blockSize <= n; lo += blockSize {
+		insertionSort(data, lo, lo+blockSize)
 	}
+	insertionSort(data, lo, n)
 
-	pivot := n / 2
-	return merge(divide(data[:pivot]), divide(data[pivot:]))
+	for ; blockSize < n; blockSize *= 2 {
+		lo = 0
+		for ; lo+2*blockSize <= n; lo += 2 * blockSize {
+			symMerge(data, lo, lo+blockSize, lo+2*blockSize)
+		}
+
+		if mid := lo + blockSize; mid < n {
+			symMerge(data, lo, mid, n)
+		}
+	}
+}
+
+// insertionSort sorts data[lo:hi], it only swaps neighbours which are out of order.
+func insertionSort(data sort.Interface, lo, hi int) {
+	for i := lo + 1; i < hi; i++ {
+		for j := i; j > lo && data.Less(j, j-1); j-- {
+			data.Swap(j, j-1)
+		}
+	}
 }
 
-func merge[T Interface](left, right []T) []T {
-	res := make([]T, 0)
-	i, j := 0, 0
+// symMerge merges the sorted data[lo:mid] and data[mid:hi] in place, by Kim and Kutzner's SymMerge. The middle of
+// data[lo:hi] splits both runs at the symmetric positions start and end, data[start:end] is rotated so the two pairs
+// of smaller runs are on either side of the middle, and both pairs are merged recursively.
+func symMerge(data sort.Interface, lo, mid, hi int) {
+	if mid-lo == 1 {
+		i, j := mid, hi
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Less(h, lo) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+
+		for k := lo; k < i-1; k++ {
+			data.Swap(k, k+1)
+		}
+
+		return
+	}
+
+	if hi-mid == 1 {
+		i, j := lo, mid
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !data.Less(mid, h) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
 
-	for i < len(left) && j < len(right) {
-		if left[i].Less(right[j]) {
-			res = append(res, left[i])
-			i++
-			continue
+		for k := mid; k > i; k-- {
+			data.Swap(k, k-1)
 		}
 
-		res = append(res, right[j])
-		j++
+		return
+	}
+
+	middle := int(uint(lo+hi) >> 1)
+	n := middle + mid
+
+	start, r := lo, mid
+	if mid > middle {
+		start, r = n-hi, middle
 	}
 
-	return append(res, append(left[i:], right[j:]...)...)
+	for p := n - 1; start < r; {
+		c := int(uint(start+r) >> 1)
+		if !data.Less(p-c, c) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < mid && mid < end {
+		rotate(data, start, mid, end)
+	}
+
+	if lo < start && start < middle {
+		symMerge(data, lo, start, middle)
+	}
+
+	if middle < end && end < hi {
+		symMerge(data, middle, end, hi)
+	}
+}
+
+// rotate swaps data[lo:mid] and data[mid:hi] by swapping blocks of equal length.
+func rotate(data sort.Interface, lo, mid, hi int) {
+	i, j := mid-lo, hi-mid
+	for i != j {
+		if i > j {
+			swapRange(data, mid-i, mid, j)
+			i -= j
+		} else {
+			swapRange(data, mid-i, mid+j-i, i)
+			j -= i
+		}
+	}
+
+	swapRange(data, mid-i, mid, i)
+}
+
+func swapRange(data sort.Interface, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data.Swap(a+i, b+i)
+	}
 }
@@ -1,6 +1,8 @@
 package merge
 
 import (
+	"context"
+	"github.com/CameronXie/algorithms-go/sort/internal/sorttest"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -42,3 +44,109 @@ type sortableInt int
 func (s sortableInt) Less(i any) bool {
 	return s < i.(sortableInt)
 }
+
+func TestSortFunc(t *testing.T) {
+	cases := map[string]struct {
+		input    []testItem
+		expected []testItem
+	}{
+		"sort by key": {
+			input: []testItem{
+				{key: 3, value: "C"},
+				{key: 1, value: "A"},
+				{key: 4, value: "D"},
+				{key: 2, value: "B"},
+			},
+			expected: []testItem{
+				{key: 1, value: "A"},
+				{key: 2, value: "B"},
+				{key: 3, value: "C"},
+				{key: 4, value: "D"},
+			},
+		},
+		"sort empty list": {
+			input:    []testItem{},
+			expected: []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortFunc(tc.input, func(a, b testItem) bool {
+				return a.key < b.key
+			})
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortOrdered(t *testing.T) {
+	cases := map[string]struct {
+		input    []string
+		expected []string
+	}{
+		"sort strings": {
+			input:    []string{"pear", "apple", "fig", "banana", "apple"},
+			expected: []string{"apple", "apple", "banana", "fig", "pear"},
+		},
+		"sort single item": {
+			input:    []string{"fig"},
+			expected: []string{"fig"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortOrdered(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+type testItem struct {
+	key   int
+	value string
+}
+
+func TestSort_Stability(t *testing.T) {
+	t.Run("Sort", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			input := make([]stabilityItem, len(items))
+			for i, item := range items {
+				input[i] = stabilityItem(item)
+			}
+
+			for i, item := range Sort(input) {
+				items[i] = sorttest.Item(item)
+			}
+		}, true)
+	})
+
+	t.Run("SortFunc", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			SortFunc(items, sorttest.Less)
+		}, true)
+	})
+
+	t.Run("ParallelSortFunc", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			_ = ParallelSortFunc(context.Background(), items, sorttest.Less, Options{Workers: 4, Threshold: 16})
+		}, true)
+	})
+
+	t.Run("Stable", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			Stable(sorttest.Data(items))
+		}, true)
+	})
+}
+
+type stabilityItem sorttest.Item
+
+func (s stabilityItem) Less(i any) bool {
+	return s.Key < i.(stabilityItem).Key
+}
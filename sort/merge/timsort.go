@@ -0,0 +1,520 @@
+package merge
+
+const (
+	minMerge  = 32
+	minGallop = 7
+)
+
+type run struct {
+	base, len int
+}
+
+// timSort is a stable natural merge sort. It finds the runs already in order, extends the short ones to minRun by
+// binary insertion sort and keeps them on a stack whose lengths grow like Fibonacci numbers, merging the top runs
+// whenever that stops holding. Merges switch to galloping when one run keeps winning, and share one buffer of at
+// most half the data.
+type timSort[T any] struct {
+	s         []T
+	less      func(a, b T) bool
+	buf       []T
+	runs      []run
+	minGallop int
+}
+
+func timsort[T any](s []T, less func(a, b T) bool) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	if n < minMerge {
+		binaryInsertionSort(s, countRun(s, less), less)
+		return
+	}
+
+	ts := &timSort[T]{s: s, less: less, minGallop: minGallop}
+	minRun := minRunLength(n)
+
+	for lo := 0; lo < n; {
+		runLen := countRun(s[lo:], less)
+		if runLen < minRun {
+			force := minRun
+			if n-lo < force {
+				force = n - lo
+			}
+
+			binaryInsertionSort(s[lo:lo+force], runLen, less)
+			runLen = force
+		}
+
+		ts.runs = append(ts.runs, run{base: lo, len: runLen})
+		ts.mergeCollapse()
+		lo += runLen
+	}
+
+	ts.mergeForceCollapse()
+}
+
+// minRunLength returns a run length between minMerge/2 and minMerge, such that n/minRun is a power of 2 or just below.
+func minRunLength(n int) int {
+	r := 0
+	for n >= minMerge {
+		r |= n & 1
+		n >>= 1
+	}
+
+	return n + r
+}
+
+// countRun returns the length of the run at the start of s, a strictly descending run is reversed in place.
+func countRun[T any](s []T, less func(a, b T) bool) int {
+	n := len(s)
+	if n < 2 {
+		return n
+	}
+
+	i := 2
+	if less(s[1], s[0]) {
+		for ; i < n && less(s[i], s[i-1]); i++ {
+		}
+
+		for lo, hi := 0, i-1; lo < hi; lo, hi = lo+1, hi-1 {
+			s[lo], s[hi] = s[hi], s[lo]
+		}
+
+		return i
+	}
+
+	for ; i < n && !less(s[i], s[i-1]); i++ {
+	}
+
+	return i
+}
+
+// binaryInsertionSort sorts s, whose first start items are sorted, inserting after the equal items to stay stable.
+func binaryInsertionSort[T any](s []T, start int, less func(a, b T) bool) {
+	if start == 0 {
+		start = 1
+	}
+
+	for i := start; i < len(s); i++ {
+		pivot := s[i]
+		lo, hi := 0, i
+		for lo < hi {
+			mid := int(uint(lo+hi) >> 1)
+			if less(pivot, s[mid]) {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+
+		copy(s[lo+1:i+1], s[lo:i])
+		s[lo] = pivot
+	}
+}
+
+// mergeCollapse merges the runs on the stack until, for the top runs X, Y and Z from the top,
+// len(Z) > len(Y) + len(X) and len(Y) > len(X). The invariant is checked one level deeper than the original
+// Timsort, which could break it.
+func (ts *timSort[T]) mergeCollapse() {
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		runs := ts.runs
+
+		if n > 0 && runs[n-1].len <= runs[n].len+runs[n+1].len ||
+			n > 1 && runs[n-2].len <= runs[n-1].len+runs[n].len {
+			if runs[n-1].len < runs[n+1].len {
+				n--
+			}
+		} else if runs[n].len > runs[n+1].len {
+			return
+		}
+
+		ts.mergeAt(n)
+	}
+}
+
+func (ts *timSort[T]) mergeForceCollapse() {
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		if n > 0 && ts.runs[n-1].len < ts.runs[n+1].len {
+			n--
+		}
+
+		ts.mergeAt(n)
+	}
+}
+
+// mergeAt merges the runs at i and i+1 on the stack. The items of the first run which are already in place, and of
+// the second run, are skipped by galloping before merging.
+func (ts *timSort[T]) mergeAt(i int) {
+	base1, len1 := ts.runs[i].base, ts.runs[i].len
+	base2, len2 := ts.runs[i+1].base, ts.runs[i+1].len
+
+	ts.runs[i].len = len1 + len2
+	if i == len(ts.runs)-3 {
+		ts.runs[i+1] = ts.runs[i+2]
+	}
+	ts.runs = ts.runs[:len(ts.runs)-1]
+
+	s := ts.s
+	k := gallopRight(s[base2], s[base1:base1+len1], 0, ts.less)
+	base1 += k
+	len1 -= k
+	if len1 == 0 {
+		return
+	}
+
+	len2 = gallopLeft(s[base1+len1-1], s[base2:base2+len2], len2-1, ts.less)
+	if len2 == 0 {
+		return
+	}
+
+	if len1 <= len2 {
+		ts.mergeLo(base1, len1, base2, len2)
+	} else {
+		ts.mergeHi(base1, len1, base2, len2)
+	}
+}
+
+// mergeLo merges the adjacent runs, copying the first and shorter run to the buffer and merging from the start.
+func (ts *timSort[T]) mergeLo(base1, len1, base2, len2 int) {
+	s, less := ts.s, ts.less
+	tmp := ts.buffer(len1)
+	copy(tmp, s[base1:base1+len1])
+
+	cursor1, cursor2, dest := 0, base2, base1
+	s[dest] = s[cursor2]
+	dest++
+	cursor2++
+	len2--
+
+	if len2 == 0 {
+		copy(s[dest:], tmp[cursor1:cursor1+len1])
+		return
+	}
+
+	if len1 == 1 {
+		copy(s[dest:], s[cursor2:cursor2+len2])
+		s[dest+len2] = tmp[cursor1]
+		return
+	}
+
+	gallop := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0
+
+		for {
+			if less(s[cursor2], tmp[cursor1]) {
+				s[dest] = s[cursor2]
+				dest++
+				cursor2++
+				count2++
+				count1 = 0
+				if len2--; len2 == 0 {
+					break outer
+				}
+			} else {
+				s[dest] = tmp[cursor1]
+				dest++
+				cursor1++
+				count1++
+				count2 = 0
+				if len1--; len1 == 1 {
+					break outer
+				}
+			}
+
+			if count1|count2 >= gallop {
+				break
+			}
+		}
+
+		for {
+			count1 = gallopRight(s[cursor2], tmp[cursor1:cursor1+len1], 0, less)
+			if count1 != 0 {
+				copy(s[dest:], tmp[cursor1:cursor1+count1])
+				dest += count1
+				cursor1 += count1
+				len1 -= count1
+				if len1 <= 1 {
+					break outer
+				}
+			}
+
+			s[dest] = s[cursor2]
+			dest++
+			cursor2++
+			if len2--; len2 == 0 {
+				break outer
+			}
+
+			count2 = gallopLeft(tmp[cursor1], s[cursor2:cursor2+len2], 0, less)
+			if count2 != 0 {
+				copy(s[dest:], s[cursor2:cursor2+count2])
+				dest += count2
+				cursor2 += count2
+				len2 -= count2
+				if len2 == 0 {
+					break outer
+				}
+			}
+
+			s[dest] = tmp[cursor1]
+			dest++
+			cursor1++
+			if len1--; len1 == 1 {
+				break outer
+			}
+
+			gallop--
+			if count1 < minGallop && count2 < minGallop {
+				break
+			}
+		}
+
+		if gallop < 0 {
+			gallop = 0
+		}
+		gallop += 2
+	}
+
+	ts.setMinGallop(gallop)
+	if len1 == 1 {
+		copy(s[dest:], s[cursor2:cursor2+len2])
+		s[dest+len2] = tmp[cursor1]
+		return
+	}
+
+	copy(s[dest:], tmp[cursor1:cursor1+len1])
+}
+
+// mergeHi merges the adjacent runs, copying the second and shorter run to the buffer and merging from the end.
+func (ts *timSort[T]) mergeHi(base1, len1, base2, len2 int) {
+	s, less := ts.s, ts.less
+	tmp := ts.buffer(len2)
+	copy(tmp, s[base2:base2+len2])
+
+	cursor1, cursor2, dest := base1+len1-1, len2-1, base2+len2-1
+	s[dest] = s[cursor1]
+	dest--
+	cursor1--
+	len1--
+
+	if len1 == 0 {
+		copy(s[dest-(len2-1):], tmp[:len2])
+		return
+	}
+
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		copy(s[dest+1:], s[cursor1+1:cursor1+1+len1])
+		s[dest] = tmp[cursor2]
+		return
+	}
+
+	gallop := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0
+
+		for {
+			if less(tmp[cursor2], s[cursor1]) {
+				s[dest] = s[cursor1]
+				dest--
+				cursor1--
+				count1++
+				count2 = 0
+				if len1--; len1 == 0 {
+					break outer
+				}
+			} else {
+				s[dest] = tmp[cursor2]
+				dest--
+				cursor2--
+				count2++
+				count1 = 0
+				if len2--; len2 == 1 {
+					break outer
+				}
+			}
+
+			if count1|count2 >= gallop {
+				break
+			}
+		}
+
+		for {
+			count1 = len1 - gallopRight(tmp[cursor2], s[base1:base1+len1], len1-1, less)
+			if count1 != 0 {
+				dest -= count1
+				cursor1 -= count1
+				len1 -= count1
+				copy(s[dest+1:], s[cursor1+1:cursor1+1+count1])
+				if len1 == 0 {
+					break outer
+				}
+			}
+
+			s[dest] = tmp[cursor2]
+			dest--
+			cursor2--
+			if len2--; len2 == 1 {
+				break outer
+			}
+
+			count2 = len2 - gallopLeft(s[cursor1], tmp[:len2], len2-1, less)
+			if count2 != 0 {
+				dest -= count2
+				cursor2 -= count2
+				len2 -= count2
+				copy(s[dest+1:], tmp[cursor2+1:cursor2+1+count2])
+				if len2 <= 1 {
+					break outer
+				}
+			}
+
+			s[dest] = s[cursor1]
+			dest--
+			cursor1--
+			if len1--; len1 == 0 {
+				break outer
+			}
+
+			gallop--
+			if count1 < minGallop && count2 < minGallop {
+				break
+			}
+		}
+
+		if gallop < 0 {
+			gallop = 0
+		}
+		gallop += 2
+	}
+
+	ts.setMinGallop(gallop)
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		copy(s[dest+1:], s[cursor1+1:cursor1+1+len1])
+		s[dest] = tmp[cursor2]
+		return
+	}
+
+	copy(s[dest-(len2-1):], tmp[:len2])
+}
+
+func (ts *timSort[T]) setMinGallop(gallop int) {
+	if gallop < 1 {
+		gallop = 1
+	}
+
+	ts.minGallop = gallop
+}
+
+// buffer returns the shared buffer with at least n items, it is grown at most up to half of the data.
+func (ts *timSort[T]) buffer(n int) []T {
+	if cap(ts.buf) < n {
+		size := 2 * cap(ts.buf)
+		if size < n {
+			size = n
+		}
+
+		if half := len(ts.s) / 2; size > half {
+			size = half
+		}
+
+		if size < n {
+			size = n
+		}
+
+		ts.buf = make([]T, size)
+	}
+
+	return ts.buf[:n]
+}
+
+// gallopLeft returns the index of the first item in the sorted s which is not less than key, searching from hint
+// with steps of growing size before a binary search.
+func gallopLeft[T any](key T, s []T, hint int, less func(a, b T) bool) int {
+	lastOfs, ofs := 0, 1
+
+	if less(s[hint], key) {
+		maxOfs := len(s) - hint
+		for ofs < maxOfs && less(s[hint+ofs], key) {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		lastOfs, ofs = lastOfs+hint, ofs+hint
+	} else {
+		maxOfs := hint + 1
+		for ofs < maxOfs && !less(s[hint-ofs], key) {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	}
+
+	for lastOfs++; lastOfs < ofs; {
+		m := lastOfs + (ofs-lastOfs)/2
+		if less(s[m], key) {
+			lastOfs = m + 1
+		} else {
+			ofs = m
+		}
+	}
+
+	return ofs
+}
+
+// gallopRight returns the index of the first item in the sorted s which is greater than key, like gallopLeft.
+func gallopRight[T any](key T, s []T, hint int, less func(a, b T) bool) int {
+	lastOfs, ofs := 0, 1
+
+	if less(key, s[hint]) {
+		maxOfs := hint + 1
+		for ofs < maxOfs && less(key, s[hint-ofs]) {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	} else {
+		maxOfs := len(s) - hint
+		for ofs < maxOfs && !less(key, s[hint+ofs]) {
+			lastOfs, ofs = ofs, ofs<<1+1
+		}
+
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		lastOfs, ofs = lastOfs+hint, ofs+hint
+	}
+
+	for lastOfs++; lastOfs < ofs; {
+		m := lastOfs + (ofs-lastOfs)/2
+		if less(key, s[m]) {
+			ofs = m
+		} else {
+			lastOfs = m + 1
+		}
+	}
+
+	return ofs
+}
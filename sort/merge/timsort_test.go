@@ -0,0 +1,305 @@
+package merge
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestTimsort_Patterns(t *testing.T) {
+	cases := map[string]struct {
+		pattern func(n int) []int
+	}{
+		"random": {
+			pattern: rand.Perm,
+		},
+		"sorted": {
+			pattern: func(n int) []int {
+				return ascending(n)
+			},
+		},
+		"reversed": {
+			pattern: func(n int) []int {
+				data := ascending(n)
+				for i := range data {
+					data[i] = n - i
+				}
+
+				return data
+			},
+		},
+		"all equal": {
+			pattern: func(n int) []int {
+				return make([]int, n)
+			},
+		},
+		"few distinct values": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = rand.Intn(4)
+				}
+
+				return data
+			},
+		},
+		"sorted batches": {
+			pattern: func(n int) []int {
+				return batches(n, 8)
+			},
+		},
+		"descending steps": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = (n - i) / 3
+				}
+
+				return data
+			},
+		},
+		"sorted with random tail": {
+			pattern: func(n int) []int {
+				data := ascending(n)
+				for i := n - n/10; i < n; i++ {
+					data[i] = rand.Intn(n)
+				}
+
+				return data
+			},
+		},
+		"interleaved": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = 2 * i
+					if i >= n/2 {
+						data[i] = 2*(i-n/2) + 1
+					}
+				}
+
+				return data
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		for _, size := range []int{0, 1, 2, 31, 32, 65, 1000, 10000} {
+			t.Run(n, func(t *testing.T) {
+				a := assert.New(t)
+				input := tc.pattern(size)
+
+				items := make([]testItem, len(input))
+				for i, key := range input {
+					items[i] = testItem{key: key, value: string(rune('a' + i%26))}
+				}
+
+				expected := make([]testItem, len(items))
+				copy(expected, items)
+				sort.SliceStable(expected, func(i, j int) bool {
+					return expected[i].key < expected[j].key
+				})
+
+				compares := 0
+				SortFunc(items, func(a, b testItem) bool {
+					compares++
+					return a.key < b.key
+				})
+
+				a.Equal(expected, items)
+				a.LessOrEqual(compares, size*bitsLen(size)+size)
+			})
+		}
+	}
+}
+
+func TestTimsort_Adaptive(t *testing.T) {
+	cases := map[string]struct {
+		input       []int
+		maxCompares int
+	}{
+		"sorted takes n-1 comparisons": {
+			input:       ascending(100000),
+			maxCompares: 100000 - 1,
+		},
+		"reversed takes n-1 comparisons": {
+			input: func() []int {
+				data := ascending(100000)
+				for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+					data[i], data[j] = data[j], data[i]
+				}
+
+				return data
+			}(),
+			maxCompares: 100000 - 1,
+		},
+		"8 sorted batches take about n log 8 comparisons": {
+			input:       batches(100000, 8),
+			maxCompares: 100000 * 4,
+		},
+		"appended sorted batch gallops": {
+			input: func() []int {
+				data := ascending(100000)
+				for i := 90000; i < len(data); i++ {
+					data[i] += 100000
+				}
+
+				data[89999], data[90000] = 150000, 99990
+
+				return data
+			}(),
+			maxCompares: 100000 + 1000,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			expected := make([]int, len(tc.input))
+			copy(expected, tc.input)
+			sort.Ints(expected)
+
+			compares := 0
+			SortFunc(tc.input, func(a, b int) bool {
+				compares++
+				return a < b
+			})
+
+			a.Equal(expected, tc.input)
+			a.LessOrEqual(compares, tc.maxCompares)
+		})
+	}
+}
+
+func TestMinRunLength(t *testing.T) {
+	cases := map[string]struct {
+		n        int
+		expected int
+	}{
+		"shorter than minMerge": {
+			n:        20,
+			expected: 20,
+		},
+		"power of 2": {
+			n:        1024,
+			expected: 16,
+		},
+		"just above power of 2": {
+			n:        1025,
+			expected: 17,
+		},
+		"large": {
+			n:        100000,
+			expected: 25,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.Equal(t, tc.expected, minRunLength(tc.n))
+		})
+	}
+}
+
+func TestGallop(t *testing.T) {
+	s := []int{1, 2, 2, 2, 3, 5, 8, 8, 13}
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	cases := map[string]struct {
+		key         int
+		left, right int
+		hint        int
+	}{
+		"before all":       {key: 0, left: 0, right: 0, hint: 0},
+		"equal run":        {key: 2, left: 1, right: 4, hint: 0},
+		"equal run at end": {key: 2, left: 1, right: 4, hint: 8},
+		"missing key":      {key: 4, left: 5, right: 5, hint: 3},
+		"equal last":       {key: 13, left: 8, right: 9, hint: 0},
+		"after all":        {key: 20, left: 9, right: 9, hint: 8},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.Equal(tc.left, gallopLeft(tc.key, s, tc.hint, less))
+			a.Equal(tc.right, gallopRight(tc.key, s, tc.hint, less))
+		})
+	}
+}
+
+func BenchmarkSortFunc(b *testing.B) {
+	cases := map[string]func(n int) []int{
+		"random": rand.Perm,
+		"sorted": ascending,
+		"sorted batches": func(n int) []int {
+			return batches(n, 16)
+		},
+	}
+
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	for n, pattern := range cases {
+		input := pattern(100000)
+		data := make([]int, len(input))
+
+		b.Run(n+" timsort", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(data, input)
+				SortFunc(data, less)
+			}
+		})
+
+		b.Run(n+" sort.SliceStable", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(data, input)
+				sort.SliceStable(data, func(i, j int) bool {
+					return data[i] < data[j]
+				})
+			}
+		})
+	}
+}
+
+func ascending(n int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = i
+	}
+
+	return data
+}
+
+// batches returns k sorted batches of random numbers, like log batches collected from k sources.
+func batches(n, k int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = rand.Intn(n)
+	}
+
+	size := (n + k - 1) / k
+	for lo := 0; lo < n; lo += size {
+		hi := lo + size
+		if hi > n {
+			hi = n
+		}
+
+		sort.Ints(data[lo:hi])
+	}
+
+	return data
+}
+
+func bitsLen(n int) int {
+	l := 0
+	for ; n > 0; n >>= 1 {
+		l++
+	}
+
+	return l
+}
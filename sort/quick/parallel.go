@@ -0,0 +1,81 @@
+package quick
+
+import (
+	"context"
+	"github.com/CameronXie/algorithms-go/sort/internal/parallel"
+	"math/bits"
+	"sort"
+)
+
+const defaultParallelThreshold = 1 << 13
+
+// Options configures the parallel sorts.
+type Options struct {
+	// Workers is the number of goroutines sorting at the same time, including the caller's, GOMAXPROCS by default.
+	Workers int
+
+	// Threshold is the length up to which a range is sorted on a single goroutine, 8192 by default.
+	Threshold int
+}
+
+// ParallelSort sorts data like Sort, sorting both sides of a partition on separate goroutines until the ranges are
+// shorter than opts.Threshold. Less and Swap are called concurrently on disjoint ranges of indices, which is safe for
+// any data backed by a slice. It returns the error of ctx when ctx is done before data is sorted, data is then left
+// in an unspecified order.
+func ParallelSort(ctx context.Context, data sort.Interface, opts Options) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if opts.Threshold <= 0 {
+		opts.Threshold = defaultParallelThreshold
+	}
+
+	n := data.Len()
+	p := &parallelSort{
+		ctx:       ctx,
+		data:      data,
+		threshold: opts.Threshold,
+		group:     parallel.New(opts.Workers),
+	}
+
+	p.sort(0, n, 2*bits.Len(uint(n)))
+	return ctx.Err()
+}
+
+func ParallelSortFunc[T any](ctx context.Context, s []T, less func(a, b T) bool, opts Options) error {
+	return ParallelSort(ctx, funcData[T]{s: s, less: less}, opts)
+}
+
+type parallelSort struct {
+	ctx       context.Context
+	data      sort.Interface
+	threshold int
+	group     *parallel.Group
+}
+
+// sort sorts data[lo:hi], it stops partitioning once ctx is done.
+func (p *parallelSort) sort(lo, hi, depth int) {
+	if hi-lo <= p.threshold || depth == 0 {
+		if p.ctx.Err() == nil {
+			introSort(p.data, lo, hi, depth)
+		}
+
+		return
+	}
+
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	lt, gt, sorted := pivotPartition(p.data, lo, hi)
+	if sorted {
+		return
+	}
+
+	p.group.Do(func() {
+		p.sort(lo, lt, depth-1)
+	}, func() {
+		p.sort(gt, hi, depth-1)
+	})
+}
@@ -0,0 +1,145 @@
+package quick
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestParallelSort(t *testing.T) {
+	cases := map[string]struct {
+		input []int
+		opts  Options
+	}{
+		"random": {
+			input: rand.Perm(10000),
+			opts:  Options{Workers: 4, Threshold: 64},
+		},
+		"sorted": {
+			input: func() []int {
+				data := make([]int, 10000)
+				for i := range data {
+					data[i] = i
+				}
+
+				return data
+			}(),
+			opts: Options{Workers: 4, Threshold: 64},
+		},
+		"few distinct values": {
+			input: func() []int {
+				data := make([]int, 10000)
+				for i := range data {
+					data[i] = rand.Intn(4)
+				}
+
+				return data
+			}(),
+			opts: Options{Workers: 4, Threshold: 64},
+		},
+		"single worker": {
+			input: rand.Perm(5000),
+			opts:  Options{Workers: 1, Threshold: 64},
+		},
+		"default options": {
+			input: rand.Perm(20000),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			expected := make([]int, len(tc.input))
+			copy(expected, tc.input)
+			sort.Ints(expected)
+
+			err := ParallelSort(context.Background(), testData(tc.input), tc.opts)
+
+			a.NoError(err)
+			a.Equal(expected, tc.input)
+		})
+	}
+}
+
+func TestParallelSortFunc(t *testing.T) {
+	a := assert.New(t)
+	items := make([]testItem, 5000)
+	for i := range items {
+		items[i] = testItem{key: rand.Intn(1000), value: string(rune('a' + i%26))}
+	}
+
+	err := ParallelSortFunc(context.Background(), items, func(a, b testItem) bool {
+		return a.key < b.key
+	}, Options{Workers: 4, Threshold: 32})
+
+	a.NoError(err)
+	a.True(sort.SliceIsSorted(items, func(i, j int) bool {
+		return items[i].key < items[j].key
+	}))
+}
+
+func TestParallelSort_Cancel(t *testing.T) {
+	cases := map[string]struct {
+		cancelAfter int
+	}{
+		"cancelled before sorting": {
+			cancelAfter: 0,
+		},
+		"cancelled while sorting": {
+			cancelAfter: 5000,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tc.cancelAfter == 0 {
+				cancel()
+			}
+
+			input := rand.Perm(100000)
+			data := make([]int, len(input))
+			copy(data, input)
+
+			compares := make(chan struct{}, tc.cancelAfter)
+			err := ParallelSortFunc(ctx, data, func(a, b int) bool {
+				select {
+				case compares <- struct{}{}:
+				default:
+					cancel()
+				}
+
+				return a < b
+			}, Options{Workers: 4, Threshold: 256})
+
+			a.ErrorIs(err, context.Canceled)
+			sort.Ints(input)
+			sort.Ints(data)
+			a.Equal(input, data)
+		})
+	}
+}
+
+func BenchmarkParallelSort(b *testing.B) {
+	input := rand.Perm(1 << 20)
+	data := make(testData, len(input))
+
+	b.Run("quick", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			Sort(data)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			_ = ParallelSort(context.Background(), data, Options{})
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package quick
+
+import (
+	"fmt"
+	"golang.org/x/exp/constraints"
+	"math/bits"
+	"sort"
+)
+
+const groupSize = 5
+
+// NthElement reorders data so that the item at k is the one which would be there if data were sorted, the items
+// before it are not greater and the items after it are not less. It is an introselect, a quickselect on the
+// partitioning of Sort which falls back to median of medians once two partitions in a row keep more than 7/8 of the
+// range, so it takes O(n) in the worst case. It panics when k is out of range.
+func NthElement(data sort.Interface, k int) {
+	n := data.Len()
+	if k < 0 || k >= n {
+		panic(indexOutOfRangeError(k, n))
+	}
+
+	introSelect(data, 0, n, k)
+}
+
+// Select returns the k-th smallest item of s, counting from 0, reordering s like NthElement.
+func Select[T constraints.Ordered](s []T, k int) T {
+	return SelectFunc(s, k, func(a, b T) bool {
+		return a < b
+	})
+}
+
+func SelectFunc[T any](s []T, k int, less func(a, b T) bool) T {
+	NthElement(funcData[T]{s: s, less: less}, k)
+	return s[k]
+}
+
+// PartialSort sorts the k smallest items of data into data[:k], the rest of data is left in an unspecified order. It
+// takes O(n + k log k). It panics when k is out of range.
+func PartialSort(data sort.Interface, k int) {
+	n := data.Len()
+	if k < 0 || k > n {
+		panic(indexOutOfRangeError(k, n+1))
+	}
+
+	switch k {
+	case 0:
+		return
+	case n:
+		Sort(data)
+		return
+	}
+
+	introSelect(data, 0, n, k-1)
+	introSort(data, 0, k-1, 2*bits.Len(uint(k)))
+}
+
+func PartialSortFunc[T any](s []T, k int, less func(a, b T) bool) {
+	PartialSort(funcData[T]{s: s, less: less}, k)
+}
+
+// introSelect moves the k-th smallest item of data[lo:hi] to k, partitioning data[lo:hi] around it. One of every two
+// partitions has to shrink the range to 7/8, so the partitions take O(n) in total before median of medians takes over.
+func introSelect(data sort.Interface, lo, hi, k int) {
+	for bad := false; hi-lo > insertionSortThreshold; {
+		n := hi - lo
+		lt, gt, sorted := pivotPartition(data, lo, hi)
+		if sorted {
+			return
+		}
+
+		switch {
+		case k < lt:
+			hi = lt
+		case k >= gt:
+			lo = gt
+		default:
+			return
+		}
+
+		if 8*(hi-lo) <= 7*n {
+			bad = false
+			continue
+		}
+
+		if bad {
+			medianOfMedians(data, lo, hi, k)
+			return
+		}
+
+		bad = true
+	}
+
+	insertionSort(data, lo, hi)
+}
+
+// medianOfMedians moves the k-th smallest item of data[lo:hi] to k like introSelect, partitioning around the median
+// of the medians of groups of 5, which leaves at least 3/10 of the items on either side of the pivot.
+func medianOfMedians(data sort.Interface, lo, hi, k int) {
+	for hi-lo > insertionSortThreshold {
+		data.Swap(lo, medianPivot(data, lo, hi))
+		lt, gt := partition(data, lo, hi)
+
+		switch {
+		case k < lt:
+			hi = lt
+		case k >= gt:
+			lo = gt
+		default:
+			return
+		}
+	}
+
+	insertionSort(data, lo, hi)
+}
+
+// medianPivot moves the median of every group of 5 in data[lo:hi] to the front, and returns the index of their
+// median.
+func medianPivot(data sort.Interface, lo, hi int) int {
+	medians := lo
+	for i := lo; i < hi; i += groupSize {
+		end := i + groupSize
+		if end > hi {
+			end = hi
+		}
+
+		insertionSort(data, i, end)
+		data.Swap(medians, i+(end-i-1)/2)
+		medians++
+	}
+
+	mid := lo + (medians-lo-1)/2
+	medianOfMedians(data, lo, medians, mid)
+
+	return mid
+}
+
+func indexOutOfRangeError(k, n int) error {
+	return fmt.Errorf(`index %v out of range [0, %v)`, k, n)
+}
@@ -0,0 +1,277 @@
+package quick
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestNthElement(t *testing.T) {
+	cases := map[string]struct {
+		pattern func(n int) []int
+	}{
+		"random": {
+			pattern: rand.Perm,
+		},
+		"sorted": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = i
+				}
+
+				return data
+			},
+		},
+		"reversed": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = n - i
+				}
+
+				return data
+			},
+		},
+		"few distinct values": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = rand.Intn(4)
+				}
+
+				return data
+			},
+		},
+		"organ pipe": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = i
+					if i > n/2 {
+						data[i] = n - i
+					}
+				}
+
+				return data
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		for _, size := range []int{1, 2, 13, 64, 1000, 10000} {
+			for _, k := range []int{0, size / 2, size * 99 / 100, size - 1} {
+				t.Run(n, func(t *testing.T) {
+					a := assert.New(t)
+					input := tc.pattern(size)
+					expected := make([]int, size)
+					copy(expected, input)
+					sort.Ints(expected)
+
+					data := &countingData{data: input}
+					NthElement(data, k)
+
+					assertNth(a, expected, data.data, k)
+					a.LessOrEqual(data.compares, 8*size+insertionSortThreshold*insertionSortThreshold)
+				})
+			}
+		}
+	}
+}
+
+func TestNthElement_OutOfRange(t *testing.T) {
+	cases := map[string]struct {
+		data     testData
+		k        int
+		expected string
+	}{
+		"negative": {
+			data:     testData{1, 2},
+			k:        -1,
+			expected: `index -1 out of range [0, 2)`,
+		},
+		"too large": {
+			data:     testData{1, 2},
+			k:        2,
+			expected: `index 2 out of range [0, 2)`,
+		},
+		"empty": {
+			data:     testData{},
+			k:        0,
+			expected: `index 0 out of range [0, 0)`,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.PanicsWithError(t, tc.expected, func() {
+				NthElement(tc.data, tc.k)
+			})
+		})
+	}
+}
+
+func TestMedianOfMedians(t *testing.T) {
+	for _, size := range []int{1, 5, 13, 100, 1001, 10000} {
+		for _, k := range []int{0, size / 3, size - 1} {
+			a := assert.New(t)
+			input := rand.Perm(size)
+			for i := range input {
+				input[i] %= 50
+			}
+
+			expected := make([]int, size)
+			copy(expected, input)
+			sort.Ints(expected)
+
+			data := &countingData{data: input}
+			medianOfMedians(data, 0, size, k)
+
+			assertNth(a, expected, data.data, k)
+			a.LessOrEqual(data.compares, 30*size)
+		}
+	}
+}
+
+func TestIntroSelect(t *testing.T) {
+	a := assert.New(t)
+	input := rand.Perm(1000)
+	expected := make([]int, len(input))
+	copy(expected, input)
+	sort.Ints(expected)
+
+	introSelect(testData(input), 0, len(input), 990)
+	assertNth(a, expected, input, 990)
+}
+
+func TestSelect(t *testing.T) {
+	cases := map[string]struct {
+		input    []float64
+		k        int
+		expected float64
+	}{
+		"smallest": {
+			input:    []float64{3.5, 1.5, 2.5},
+			k:        0,
+			expected: 1.5,
+		},
+		"largest": {
+			input:    []float64{3.5, 1.5, 2.5},
+			k:        2,
+			expected: 3.5,
+		},
+		"p99 latency": {
+			input: func() []float64 {
+				data := make([]float64, 1000)
+				for i, v := range rand.Perm(1000) {
+					data[i] = float64(v) / 10
+				}
+
+				return data
+			}(),
+			k:        989,
+			expected: 98.9,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Select(tc.input, tc.k))
+		})
+	}
+}
+
+func TestSelectFunc(t *testing.T) {
+	a := assert.New(t)
+	items := []testItem{{key: 3, value: "C"}, {key: 1, value: "A"}, {key: 4, value: "D"}, {key: 2, value: "B"}}
+
+	item := SelectFunc(items, 1, func(a, b testItem) bool {
+		return a.key < b.key
+	})
+
+	a.Equal(testItem{key: 2, value: "B"}, item)
+	a.Equal(item, items[1])
+}
+
+func TestPartialSort(t *testing.T) {
+	for _, size := range []int{0, 1, 13, 100, 10000} {
+		for _, k := range []int{0, 1, size / 10, size - 1, size} {
+			if k < 0 || k > size {
+				continue
+			}
+
+			a := assert.New(t)
+			input := rand.Perm(size)
+			expected := make([]int, size)
+			copy(expected, input)
+			sort.Ints(expected)
+
+			PartialSort(testData(input), k)
+			a.Equal(expected[:k], input[:k])
+
+			rest := make([]int, size-k)
+			copy(rest, input[k:])
+			sort.Ints(rest)
+			a.Equal(expected[k:], rest)
+		}
+	}
+}
+
+func TestPartialSort_OutOfRange(t *testing.T) {
+	assert.PanicsWithError(t, `index 3 out of range [0, 3)`, func() {
+		PartialSort(testData{1, 2}, 3)
+	})
+}
+
+func TestPartialSortFunc(t *testing.T) {
+	a := assert.New(t)
+	items := []testItem{{key: 3, value: "C"}, {key: 1, value: "A"}, {key: 4, value: "D"}, {key: 2, value: "B"}}
+
+	PartialSortFunc(items, 2, func(a, b testItem) bool {
+		return a.key < b.key
+	})
+
+	a.Equal([]testItem{{key: 1, value: "A"}, {key: 2, value: "B"}}, items[:2])
+	a.ElementsMatch([]testItem{{key: 3, value: "C"}, {key: 4, value: "D"}}, items[2:])
+}
+
+func BenchmarkSelect(b *testing.B) {
+	input := rand.Perm(1000000)
+	data := make([]int, len(input))
+	k := len(input) * 99 / 100
+
+	b.Run("select", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			Select(data, k)
+		}
+	})
+
+	b.Run("sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			SortOrdered(data)
+		}
+	})
+}
+
+// assertNth asserts that data has the item of sorted at k, and is partitioned around it.
+func assertNth(a *assert.Assertions, sorted, data []int, k int) {
+	a.Equal(sorted[k], data[k])
+
+	for i, v := range data {
+		if i < k {
+			a.LessOrEqual(v, data[k])
+		}
+
+		if i > k {
+			a.GreaterOrEqual(v, data[k])
+		}
+	}
+
+	copied := make([]int, len(data))
+	copy(copied, data)
+	sort.Ints(copied)
+	a.Equal(sorted, copied)
+}
@@ -1,39 +1,243 @@
 package quick
 
 import (
+	"golang.org/x/exp/constraints"
+	"math/bits"
 	"sort"
 )
 
+const (
+	insertionSortThreshold = 12
+	nintherThreshold       = 50
+	partialInsertionSteps  = 5
+)
+
+type order int
+
+const (
+	unordered order = iota
+	ascending
+	descending
+)
+
+// Sort is an introsort. It picks the pivot by median of three, or by Tukey's ninther on larger ranges, and
+// partitions into less, equal and greater than the pivot, so duplicates are never sorted again. It recurses into the
+// smaller side only, falls back to heapsort once the depth limit is reached and uses insertion sort on small ranges.
+// Like pdqsort, a range whose pivot samples are in order is checked for being sorted, after reversing it when the
+// samples are descending, so sorted and reversed input take linear time. It is not stable, use merge.SortFunc or
+// merge.Stable to keep equal items in order.
 func Sort(data sort.Interface) {
-	quickSort(data, 0, data.Len()-1)
+	n := data.Len()
+	introSort(data, 0, n, 2*bits.Len(uint(n)))
+}
+
+func SortFunc[T any](s []T, less func(a, b T) bool) {
+	Sort(funcData[T]{s: s, less: less})
+}
+
+func SortOrdered[T constraints.Ordered](s []T) {
+	SortFunc(s, func(a, b T) bool {
+		return a < b
+	})
+}
+
+type funcData[T any] struct {
+	s    []T
+	less func(a, b T) bool
+}
+
+func (d funcData[T]) Len() int {
+	return len(d.s)
+}
+
+func (d funcData[T]) Less(i, j int) bool {
+	return d.less(d.s[i], d.s[j])
+}
+
+func (d funcData[T]) Swap(i, j int) {
+	d.s[i], d.s[j] = d.s[j], d.s[i]
+}
+
+// introSort sorts data[lo:hi].
+func introSort(data sort.Interface, lo, hi, depth int) {
+	for hi-lo > insertionSortThreshold {
+		if depth == 0 {
+			heapSort(data, lo, hi)
+			return
+		}
+
+		depth--
+		lt, gt, sorted := pivotPartition(data, lo, hi)
+		if sorted {
+			return
+		}
+
+		if lt-lo < hi-gt {
+			introSort(data, lo, lt, depth)
+			lo = gt
+		} else {
+			introSort(data, gt, hi, depth)
+			hi = lt
+		}
+	}
+
+	insertionSort(data, lo, hi)
+}
+
+// pivotPartition partitions data[lo:hi] around the chosen pivot like partition. It reports sorted instead when the
+// range turns out to be sorted, which is only checked when the pivot samples are in order.
+func pivotPartition(data sort.Interface, lo, hi int) (int, int, bool) {
+	pivot, hint := choosePivot(data, lo, hi)
+	if hint == descending {
+		reverse(data, lo, hi)
+		pivot = hi - 1 - (pivot - lo)
+		hint = ascending
+	}
+
+	if hint == ascending && partialInsertionSort(data, lo, hi) {
+		return lo, hi, true
+	}
+
+	data.Swap(lo, pivot)
+	lt, gt := partition(data, lo, hi)
+
+	return lt, gt, false
+}
+
+// choosePivot returns the index of the pivot of data[lo:hi], and the order of the samples it compared.
+func choosePivot(data sort.Interface, lo, hi int) (int, order) {
+	n := hi - lo
+	first, mid, last := lo, lo+n/2, hi-1
+	swaps, maxSwaps := 0, 3
+
+	if n >= nintherThreshold {
+		s := n / 8
+		first = median(data, first, first+s, first+2*s, &swaps)
+		mid = median(data, mid-s, mid, mid+s, &swaps)
+		last = median(data, last-2*s, last-s, last, &swaps)
+		maxSwaps = 12
+	}
+
+	pivot := median(data, first, mid, last, &swaps)
+	switch swaps {
+	case 0:
+		return pivot, ascending
+	case maxSwaps:
+		return pivot, descending
+	}
+
+	return pivot, unordered
 }
 
-func quickSort(data sort.Interface, min, max int) {
-	if min >= max {
-		return
+// median returns the index of the median of a, b and c, swaps counts the pairs which are out of order.
+func median(data sort.Interface, a, b, c int, swaps *int) int {
+	if data.Less(b, a) {
+		*swaps++
+		a, b = b, a
 	}
 
-	pivot := max
-	for left, right := min, max-1; left <= right; {
-		for ; data.Less(left, max) && left < right; left++ {
+	if data.Less(c, b) {
+		*swaps++
+		b = c
+		if data.Less(b, a) {
+			*swaps++
+			b = a
+		}
+	}
+
+	return b
+}
+
+// partialInsertionSort fixes up to a few pairs which are out of order in data[lo:hi], it reports whether the range
+// is sorted afterwards.
+func partialInsertionSort(data sort.Interface, lo, hi int) bool {
+	i := lo + 1
+	for step := 0; step < partialInsertionSteps; step++ {
+		for i < hi && !data.Less(i, i-1) {
+			i++
 		}
 
-		for ; data.Less(max, right) && left < right; right-- {
+		if i == hi {
+			return true
 		}
 
-		if left != right {
-			data.Swap(left, right)
-			continue
+		data.Swap(i, i-1)
+		for j := i - 1; j > lo && data.Less(j, j-1); j-- {
+			data.Swap(j, j-1)
 		}
 
-		if data.Less(max, right) {
-			data.Swap(max, right)
-			pivot = right
+		for j := i + 1; j < hi && data.Less(j, j-1); j++ {
+			data.Swap(j, j-1)
 		}
+	}
 
-		break
+	return false
+}
+
+func reverse(data sort.Interface, lo, hi int) {
+	for i, j := lo, hi-1; i < j; i, j = i+1, j-1 {
+		data.Swap(i, j)
 	}
+}
 
-	quickSort(data, 0, pivot-1)
-	quickSort(data, pivot+1, max)
+// partition partitions data[lo:hi] around the pivot at lo, and returns lt and gt such that data[lo:lt] is less than,
+// data[lt:gt] is equal to and data[gt:hi] is greater than the pivot. The pivot is always at lt while partitioning.
+func partition(data sort.Interface, lo, hi int) (int, int) {
+	lt, gt := lo, hi
+	for i := lo + 1; i < gt; {
+		switch {
+		case data.Less(i, lt):
+			data.Swap(i, lt)
+			lt++
+			i++
+		case data.Less(lt, i):
+			gt--
+			data.Swap(i, gt)
+		default:
+			i++
+		}
+	}
+
+	return lt, gt
+}
+
+func insertionSort(data sort.Interface, lo, hi int) {
+	for i := lo + 1; i < hi; i++ {
+		for j := i; j > lo && data.Less(j, j-1); j-- {
+			data.Swap(j, j-1)
+		}
+	}
+}
+
+func heapSort(data sort.Interface, lo, hi int) {
+	n := hi - lo
+	for i := (n - 1) / 2; i >= 0; i-- {
+		siftDown(data, lo, i, n)
+	}
+
+	for end := n - 1; end > 0; end-- {
+		data.Swap(lo, lo+end)
+		siftDown(data, lo, 0, end)
+	}
+}
+
+// siftDown sifts the node at root down the max heap data[lo:lo+n].
+func siftDown(data sort.Interface, lo, root, n int) {
+	for {
+		child := 2*root + 1
+		if child >= n {
+			return
+		}
+
+		if child+1 < n && data.Less(lo+child, lo+child+1) {
+			child++
+		}
+
+		if !data.Less(lo+root, lo+child) {
+			return
+		}
+
+		data.Swap(lo+root, lo+child)
+		root = child
+	}
 }
@@ -1,7 +1,11 @@
 package quick
 
 import (
+	"context"
+	"github.com/CameronXie/algorithms-go/sort/internal/sorttest"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
 	"testing"
 )
 
@@ -26,6 +30,10 @@ func TestSort(t *testing.T) {
 			input:    testData{},
 			expected: testData{},
 		},
+		"sort duplicated items": {
+			input:    testData{3, 1, 3, 2, 1, 3, 2, 2, 1, 3, 1, 2, 3, 3, 1, 2},
+			expected: testData{1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3, 3},
+		},
 	}
 
 	for n, tc := range cases {
@@ -38,6 +46,200 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSort_Patterns(t *testing.T) {
+	cases := map[string]struct {
+		pattern func(n int) []int
+	}{
+		"random": {
+			pattern: func(n int) []int {
+				return rand.Perm(n)
+			},
+		},
+		"sorted": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = i
+				}
+
+				return data
+			},
+		},
+		"reversed": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = n - i
+				}
+
+				return data
+			},
+		},
+		"all equal": {
+			pattern: func(n int) []int {
+				return make([]int, n)
+			},
+		},
+		"few distinct values": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = rand.Intn(4)
+				}
+
+				return data
+			},
+		},
+		"organ pipe": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = i
+					if i > n/2 {
+						data[i] = n - i
+					}
+				}
+
+				return data
+			},
+		},
+		"sawtooth": {
+			pattern: func(n int) []int {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = i % 16
+				}
+
+				return data
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		for _, size := range []int{1, 2, 13, 64, 1000, 10000} {
+			t.Run(n, func(t *testing.T) {
+				a := assert.New(t)
+				input := tc.pattern(size)
+				expected := make([]int, size)
+				copy(expected, input)
+				sort.Ints(expected)
+
+				data := &countingData{data: input}
+				Sort(data)
+
+				a.Equal(expected, data.data)
+				a.LessOrEqual(data.compares, 4*size*bitsLen(size)+size)
+			})
+		}
+	}
+}
+
+func TestHeapSort(t *testing.T) {
+	cases := map[string]struct {
+		input    testData
+		lo, hi   int
+		expected testData
+	}{
+		"sort whole list": {
+			input:    testData{6, 2, 7, 1, 9, 10, 8, 3, 5, 4},
+			lo:       0,
+			hi:       10,
+			expected: testData{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		"sort sub list": {
+			input:    testData{6, 2, 7, 1, 9, 10, 8, 3, 5, 4},
+			lo:       2,
+			hi:       7,
+			expected: testData{6, 2, 1, 7, 8, 9, 10, 3, 5, 4},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			heapSort(tc.input, tc.lo, tc.hi)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestIntroSort_DepthLimit(t *testing.T) {
+	a := assert.New(t)
+	data := testData(rand.Perm(1000))
+
+	introSort(data, 0, len(data), 0)
+	a.True(sort.IsSorted(data))
+}
+
+func BenchmarkSort(b *testing.B) {
+	cases := map[string]func(n int) []int{
+		"random": rand.Perm,
+		"sorted": func(n int) []int {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = i
+			}
+
+			return data
+		},
+		"few distinct values": func(n int) []int {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = rand.Intn(4)
+			}
+
+			return data
+		},
+	}
+
+	for n, pattern := range cases {
+		input := pattern(100000)
+		data := make(testData, len(input))
+
+		b.Run(n+" quick", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(data, input)
+				Sort(data)
+			}
+		})
+
+		b.Run(n+" sort", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(data, input)
+				sort.Sort(data)
+			}
+		})
+	}
+}
+
+type countingData struct {
+	data     []int
+	compares int
+}
+
+func (d *countingData) Len() int {
+	return len(d.data)
+}
+
+func (d *countingData) Less(i, j int) bool {
+	d.compares++
+	return d.data[i] < d.data[j]
+}
+
+func (d *countingData) Swap(i, j int) {
+	d.data[i], d.data[j] = d.data[j], d.data[i]
+}
+
+func bitsLen(n int) int {
+	l := 0
+	for ; n > 0; n >>= 1 {
+		l++
+	}
+
+	return l
+}
+
 type testData []int
 
 func (d testData) Len() int {
@@ -51,3 +253,90 @@ func (d testData) Less(i, j int) bool {
 func (d testData) Swap(i, j int) {
 	d[i], d[j] = d[j], d[i]
 }
+
+func TestSortFunc(t *testing.T) {
+	cases := map[string]struct {
+		input    []testItem
+		expected []testItem
+	}{
+		"sort by key": {
+			input: []testItem{
+				{key: 3, value: "C"},
+				{key: 1, value: "A"},
+				{key: 4, value: "D"},
+				{key: 2, value: "B"},
+			},
+			expected: []testItem{
+				{key: 1, value: "A"},
+				{key: 2, value: "B"},
+				{key: 3, value: "C"},
+				{key: 4, value: "D"},
+			},
+		},
+		"sort empty list": {
+			input:    []testItem{},
+			expected: []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortFunc(tc.input, func(a, b testItem) bool {
+				return a.key < b.key
+			})
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortOrdered(t *testing.T) {
+	cases := map[string]struct {
+		input    []string
+		expected []string
+	}{
+		"sort strings": {
+			input:    []string{"pear", "apple", "fig", "banana", "apple"},
+			expected: []string{"apple", "apple", "banana", "fig", "pear"},
+		},
+		"sort single item": {
+			input:    []string{"fig"},
+			expected: []string{"fig"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortOrdered(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+type testItem struct {
+	key   int
+	value string
+}
+
+func TestSort_Stability(t *testing.T) {
+	t.Run("Sort", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			Sort(sorttest.Data(items))
+		}, false)
+	})
+
+	t.Run("SortFunc", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			SortFunc(items, sorttest.Less)
+		}, false)
+	})
+
+	t.Run("ParallelSortFunc", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			_ = ParallelSortFunc(context.Background(), items, sorttest.Less, Options{Workers: 4, Threshold: 16})
+		}, false)
+	})
+}
@@ -0,0 +1,158 @@
+package radix
+
+import (
+	"github.com/CameronXie/algorithms-go/sort/internal/sortkey"
+	"golang.org/x/exp/constraints"
+)
+
+const insertionSortThreshold = 16
+
+// Bytes is a byte string key, either a string or a byte slice.
+type Bytes interface {
+	~string | ~[]byte
+}
+
+func Sort[T constraints.Integer](s []T) {
+	SortBy(s, func(item T) T {
+		return item
+	})
+}
+
+// SortBy is a stable LSD radix sort of s by the integer key, one byte per pass from the least significant. A pass on
+// a byte which is the same in every key is skipped, so small keys take fewer passes. The keys are computed once.
+func SortBy[T any, K constraints.Integer](s []T, key func(item T) K) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	keys := make([]uint64, n)
+	for i, item := range s {
+		keys[i] = sortkey.Integer(key(item))
+	}
+
+	items, buf, bufKeys := s, make([]T, n), make([]uint64, n)
+	for shift := 0; shift < 64; shift += 8 {
+		var offsets [256]int
+		for _, k := range keys {
+			offsets[byte(k>>shift)]++
+		}
+
+		if offsets[byte(keys[0]>>shift)] == n {
+			continue
+		}
+
+		for b, total := 0, 0; b < len(offsets); b++ {
+			offsets[b], total = total, total+offsets[b]
+		}
+
+		for i, k := range keys {
+			b := byte(k >> shift)
+			buf[offsets[b]], bufKeys[offsets[b]] = items[i], k
+			offsets[b]++
+		}
+
+		items, buf = buf, items
+		keys, bufKeys = bufKeys, keys
+	}
+
+	if &items[0] != &s[0] {
+		copy(s, items)
+	}
+}
+
+func SortStrings[S Bytes](s []S) {
+	SortStringsBy(s, func(item S) S {
+		return item
+	})
+}
+
+type keyed[T any, S Bytes] struct {
+	key  S
+	item T
+}
+
+// SortStringsBy is a stable MSD radix sort of s by the byte string key, a key goes before the keys it is a prefix
+// of. Every bucket of the first byte is sorted by the next byte, and small buckets are sorted by insertion sort.
+func SortStringsBy[T any, S Bytes](s []T, key func(item T) S) {
+	items := make([]keyed[T, S], len(s))
+	for i, item := range s {
+		items[i] = keyed[T, S]{key: key(item), item: item}
+	}
+
+	msd(items, make([]keyed[T, S], len(s)), 0)
+
+	for i := range items {
+		s[i] = items[i].item
+	}
+}
+
+// msd sorts items, whose keys share their first depth bytes, by the bytes from depth.
+func msd[T any, S Bytes](items, buf []keyed[T, S], depth int) {
+	for len(items) > insertionSortThreshold {
+		// offsets[0] counts the keys ending at depth, which go first.
+		var offsets [257]int
+		for _, item := range items {
+			offsets[bucket(item.key, depth)]++
+		}
+
+		if offsets[0] == len(items) {
+			return
+		}
+
+		if b := bucket(items[0].key, depth); b != 0 && offsets[b] == len(items) {
+			depth++
+			continue
+		}
+
+		var starts [258]int
+		for b := range offsets {
+			starts[b+1] = starts[b] + offsets[b]
+		}
+
+		next := starts
+		for _, item := range items {
+			b := bucket(item.key, depth)
+			buf[next[b]] = item
+			next[b]++
+		}
+		copy(items, buf)
+
+		for b := 1; b < len(offsets); b++ {
+			if offsets[b] > 1 {
+				msd(items[starts[b]:starts[b+1]], buf[starts[b]:starts[b+1]], depth+1)
+			}
+		}
+
+		return
+	}
+
+	insertionSort(items, depth)
+}
+
+func bucket[S Bytes](key S, depth int) int {
+	if depth < len(key) {
+		return int(key[depth]) + 1
+	}
+
+	return 0
+}
+
+func insertionSort[T any, S Bytes](items []keyed[T, S], depth int) {
+	for i := 1; i < len(items); i++ {
+		for j := i; j > 0 && less(items[j].key, items[j-1].key, depth); j-- {
+			items[j], items[j-1] = items[j-1], items[j]
+		}
+	}
+}
+
+// less compares a and b from depth.
+func less[S Bytes](a, b S, depth int) bool {
+	for i := depth; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return len(a) < len(b)
+}
@@ -0,0 +1,269 @@
+package radix
+
+import (
+	"github.com/CameronXie/algorithms-go/sort/internal/sorttest"
+	"github.com/CameronXie/algorithms-go/sort/quick"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := map[string]struct {
+		input    []int
+		expected []int
+	}{
+		"sort random list": {
+			input:    []int{6, 2, 7, 1, 9, 10, 8, 3, 5, 4},
+			expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		"sort negative items": {
+			input:    []int{3, -1, math.MaxInt64, 0, math.MinInt64, -300, 256},
+			expected: []int{math.MinInt64, -300, -1, 0, 3, 256, math.MaxInt64},
+		},
+		"sort empty list": {
+			input:    []int{},
+			expected: []int{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			Sort(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSort_Types(t *testing.T) {
+	a := assert.New(t)
+
+	int8s := []int8{127, -128, 0, -1, 1}
+	Sort(int8s)
+	a.Equal([]int8{-128, -1, 0, 1, 127}, int8s)
+
+	uint64s := []uint64{math.MaxUint64, 0, 1 << 63, 1}
+	Sort(uint64s)
+	a.Equal([]uint64{0, 1, 1 << 63, math.MaxUint64}, uint64s)
+
+	ints := rand.Perm(10000)
+	for i := range ints {
+		ints[i] -= 5000
+	}
+
+	expected := make([]int, len(ints))
+	copy(expected, ints)
+	sort.Ints(expected)
+
+	Sort(ints)
+	a.Equal(expected, ints)
+}
+
+func TestSortBy(t *testing.T) {
+	cases := map[string]struct {
+		input    []testItem
+		expected []testItem
+	}{
+		"keep the order of equal keys": {
+			input: []testItem{
+				{key: 3, value: "A"},
+				{key: -1, value: "B"},
+				{key: 3, value: "C"},
+				{key: 1000, value: "D"},
+				{key: -1, value: "E"},
+				{key: 3, value: "F"},
+			},
+			expected: []testItem{
+				{key: -1, value: "B"},
+				{key: -1, value: "E"},
+				{key: 3, value: "A"},
+				{key: 3, value: "C"},
+				{key: 3, value: "F"},
+				{key: 1000, value: "D"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortBy(tc.input, func(item testItem) int {
+				return item.key
+			})
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	cases := map[string]struct {
+		input    []string
+		expected []string
+	}{
+		"sort strings": {
+			input:    []string{"pear", "apple", "fig", "banana", "apple", "", "app", "applesauce"},
+			expected: []string{"", "app", "apple", "apple", "applesauce", "banana", "fig", "pear"},
+		},
+		"sort empty list": {
+			input:    []string{},
+			expected: []string{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			SortStrings(tc.input)
+			a.Equal(tc.expected, tc.input)
+		})
+	}
+}
+
+func TestSortStrings_Random(t *testing.T) {
+	cases := map[string]struct {
+		size   int
+		length int
+	}{
+		"short strings": {
+			size:   10000,
+			length: 3,
+		},
+		"long strings with shared prefix": {
+			size:   5000,
+			length: 20,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			strs := make([]string, tc.size)
+			bytes := make([][]byte, tc.size)
+			for i := range strs {
+				b := make([]byte, rand.Intn(tc.length+1))
+				for j := range b {
+					b[j] = byte('a' + rand.Intn(3))
+				}
+
+				strs[i], bytes[i] = string(b), b
+			}
+
+			expected := make([]string, len(strs))
+			copy(expected, strs)
+			sort.Strings(expected)
+
+			SortStrings(strs)
+			a.Equal(expected, strs)
+
+			SortStrings(bytes)
+			for i := range bytes {
+				a.Equal(expected[i], string(bytes[i]))
+			}
+		})
+	}
+}
+
+func TestSortStringsBy(t *testing.T) {
+	cases := map[string]struct {
+		size int
+	}{
+		"keep the order of equal keys": {
+			size: 5000,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			items := make([]testItem, tc.size)
+			for i := range items {
+				items[i] = testItem{key: i, value: strconv.Itoa(rand.Intn(100))}
+			}
+
+			expected := make([]testItem, len(items))
+			copy(expected, items)
+			sort.SliceStable(expected, func(i, j int) bool {
+				return expected[i].value < expected[j].value
+			})
+
+			SortStringsBy(items, func(item testItem) string {
+				return item.value
+			})
+			a.Equal(expected, items)
+		})
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	input := make([]int, 1<<20)
+	for i := range input {
+		input[i] = rand.Int()
+	}
+	data := make([]int, len(input))
+
+	b.Run("radix", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			Sort(data)
+		}
+	})
+
+	b.Run("quick", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			quick.Sort(sort.IntSlice(data))
+		}
+	})
+}
+
+func BenchmarkSortStrings(b *testing.B) {
+	input := make([]string, 1<<18)
+	for i := range input {
+		input[i] = strconv.Itoa(rand.Int())
+	}
+	data := make([]string, len(input))
+
+	b.Run("radix", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			SortStrings(data)
+		}
+	})
+
+	b.Run("quick", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, input)
+			quick.Sort(sort.StringSlice(data))
+		}
+	})
+}
+
+type testItem struct {
+	key   int
+	value string
+}
+
+func TestSortBy_Stability(t *testing.T) {
+	t.Run("SortBy", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			SortBy(items, func(item sorttest.Item) int {
+				return item.Key
+			})
+		}, true)
+	})
+
+	t.Run("SortStringsBy", func(t *testing.T) {
+		sorttest.Check(t, func(items []sorttest.Item) {
+			SortStringsBy(items, func(item sorttest.Item) string {
+				return strconv.Itoa(item.Key + 500000)
+			})
+		}, true)
+	})
+}
@@ -1,7 +1,11 @@
 package selection
 
-import "sort"
+import (
+	"golang.org/x/exp/constraints"
+	"sort"
+)
 
+// Sort is not stable, swapping the minimum into place can move an item behind an equal one.
 func Sort(data sort.Interface) {
 	n := data.Len()
 
@@ -16,3 +20,25 @@ func Sort(data sort.Interface) {
 		data.Swap(i, minIdx)
 	}
 }
+
+// SortFunc is not stable like Sort.
+func SortFunc[T any](s []T, less func(a, b T) bool) {
+	n := len(s)
+
+	for i := 0; i < n; i++ {
+		minIdx := i
+		for j := i + 1; j < n; j++ {
+			if less(s[j], s[minIdx]) {
+				minIdx = j
+			}
+		}
+
+		s[i], s[minIdx] = s[minIdx], s[i]
+	}
+}
+
+func SortOrdered[T constraints.Ordered](s []T) {
+	SortFunc(s, func(a, b T) bool {
+		return a < b
+	})
+}
@@ -0,0 +1,279 @@
+package heap
+
+import "sync"
+
+type binomialNode[T Node] struct {
+	data   T
+	degree int
+
+	parent  *binomialNode[T]
+	child   *binomialNode[T]
+	sibling *binomialNode[T]
+}
+
+// BinomialHeap is a mergeable heap with O(log n) Push, Pop and decrease key, and O(log n + min(n, m)) Meld.
+type BinomialHeap[T Node] struct {
+	// head is the root list ordered by degree.
+	head *binomialNode[T]
+	m    map[string]*binomialNode[T]
+	mu   sync.RWMutex
+}
+
+func (h *BinomialHeap[T]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.m)
+}
+
+func (h *BinomialHeap[T]) Peek() Node {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.head == nil {
+		return nil
+	}
+
+	_, top := h.top()
+	return top.data
+}
+
+func (h *BinomialHeap[T]) Find(id string) (Node, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	return n.data, nil
+}
+
+func (h *BinomialHeap[T]) Pop() Node {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.head == nil {
+		return nil
+	}
+
+	prev, top := h.top()
+	h.removeRoot(prev, top)
+	delete(h.m, top.data.GetUniqueID())
+
+	return top.data
+}
+
+func (h *BinomialHeap[T]) Push(node T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := node.GetUniqueID()
+	if _, ok := h.m[id]; ok {
+		return itemAlreadyExistsError(id)
+	}
+
+	n := &binomialNode[T]{data: node}
+	h.m[id] = n
+	h.head = unionBinomial(h.head, n)
+
+	return nil
+}
+
+func (h *BinomialHeap[T]) Update(id string, updates func(old T) T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return itemNotExistsError(id)
+	}
+
+	old := n.data
+	data := updates(old)
+
+	// decrease key sifts the data up, otherwise the node is reinserted.
+	if !old.Less(data) {
+		delete(h.m, id)
+		n.data = data
+		h.m[data.GetUniqueID()] = n
+		h.up(n, false)
+		return nil
+	}
+
+	root := h.up(n, true)
+	h.removeRoot(h.prevRoot(root), root)
+	delete(h.m, id)
+
+	n = &binomialNode[T]{data: data}
+	h.m[data.GetUniqueID()] = n
+	h.head = unionBinomial(h.head, n)
+
+	return nil
+}
+
+func (h *BinomialHeap[T]) Remove(id string) (Node, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	data := n.data
+	root := h.up(n, true)
+	h.removeRoot(h.prevRoot(root), root)
+	delete(h.m, id)
+
+	return data, nil
+}
+
+// Meld unions the root lists of h and other in O(log n) and merges their id indexes.
+func (h *BinomialHeap[T]) Meld(other *BinomialHeap[T]) error {
+	if h == other {
+		return meldItselfError()
+	}
+
+	defer lockPair(&h.mu, &other.mu)()
+
+	m, err := meldIndex(h.m, other.m)
+	if err != nil {
+		return err
+	}
+
+	h.m = m
+	h.head = unionBinomial(h.head, other.head)
+	other.head = nil
+	other.m = make(map[string]*binomialNode[T])
+
+	return nil
+}
+
+// top returns the root with the highest priority and the root before it.
+func (h *BinomialHeap[T]) top() (prev, top *binomialNode[T]) {
+	top = h.head
+	for p, n := h.head, h.head.sibling; n != nil; p, n = n, n.sibling {
+		if n.data.Less(top.data) {
+			prev, top = p, n
+		}
+	}
+
+	return prev, top
+}
+
+func (h *BinomialHeap[T]) prevRoot(root *binomialNode[T]) *binomialNode[T] {
+	var prev *binomialNode[T]
+	for n := h.head; n != root; n = n.sibling {
+		prev = n
+	}
+
+	return prev
+}
+
+func (h *BinomialHeap[T]) removeRoot(prev, root *binomialNode[T]) {
+	if prev == nil {
+		h.head = root.sibling
+	} else {
+		prev.sibling = root.sibling
+	}
+
+	// children are ordered by decreasing degree, reverse them into a root list.
+	var children *binomialNode[T]
+	for c := root.child; c != nil; {
+		next := c.sibling
+		c.parent = nil
+		c.sibling = children
+		children = c
+		c = next
+	}
+
+	h.head = unionBinomial(h.head, children)
+}
+
+// up swaps the data of node n with its ancestors while it has a higher priority, or all the way to the root
+// when force is true. It returns the node which holds the data at the end.
+func (h *BinomialHeap[T]) up(n *binomialNode[T], force bool) *binomialNode[T] {
+	for n.parent != nil && (force || n.data.Less(n.parent.data)) {
+		p := n.parent
+		n.data, p.data = p.data, n.data
+		h.m[n.data.GetUniqueID()] = n
+		h.m[p.data.GetUniqueID()] = p
+		n = p
+	}
+
+	return n
+}
+
+func unionBinomial[T Node](a, b *binomialNode[T]) *binomialNode[T] {
+	head := mergeRootLists(a, b)
+	if head == nil {
+		return nil
+	}
+
+	var prev *binomialNode[T]
+	for current, next := head, head.sibling; next != nil; next = current.sibling {
+		if current.degree != next.degree || (next.sibling != nil && next.sibling.degree == current.degree) {
+			prev, current = current, next
+			continue
+		}
+
+		if !next.data.Less(current.data) {
+			current.sibling = next.sibling
+			linkBinomial(next, current)
+			continue
+		}
+
+		if prev == nil {
+			head = next
+		} else {
+			prev.sibling = next
+		}
+
+		linkBinomial(current, next)
+		current = next
+	}
+
+	return head
+}
+
+func mergeRootLists[T Node](a, b *binomialNode[T]) *binomialNode[T] {
+	var head, tail *binomialNode[T]
+	for a != nil || b != nil {
+		var n *binomialNode[T]
+		if b == nil || (a != nil && a.degree <= b.degree) {
+			n, a = a, a.sibling
+		} else {
+			n, b = b, b.sibling
+		}
+
+		if tail == nil {
+			head = n
+		} else {
+			tail.sibling = n
+		}
+
+		tail = n
+	}
+
+	return head
+}
+
+// linkBinomial makes child the first child of parent, both trees have the same degree.
+func linkBinomial[T Node](child, parent *binomialNode[T]) {
+	child.parent = parent
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree++
+}
+
+// NewBinomial creates a BinomialHeap from a copy of items, items with duplicated ids are skipped.
+func NewBinomial[T Node](items []T) *BinomialHeap[T] {
+	h := BinomialHeap[T]{m: make(map[string]*binomialNode[T])}
+	for _, item := range items {
+		_ = h.Push(item)
+	}
+
+	return &h
+}
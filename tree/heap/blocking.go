@@ -0,0 +1,192 @@
+package heap
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrClosed = errors.New("queue closed")
+	ErrFull   = errors.New("queue full")
+)
+
+// BlockingQueue is a concurrent priority queue on top of DHeap. PopWait blocks until a node is available, and
+// PushWait blocks while the queue is at capacity. A capacity of 0 means the queue is unbounded.
+type BlockingQueue[T Node] struct {
+	heap     *DHeap[T]
+	capacity int
+	closed   bool
+	mu       sync.Mutex
+
+	// changed is closed and replaced whenever the queue is pushed, popped or closed, to wake up the waiters.
+	changed chan struct{}
+}
+
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.heap.Len()
+}
+
+func (q *BlockingQueue[T]) Peek() Node {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.heap.Peek()
+}
+
+func (q *BlockingQueue[T]) Find(id string) (Node, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.heap.Find(id)
+}
+
+// Push adds node without blocking, it fails with ErrFull when the queue is at capacity.
+func (q *BlockingQueue[T]) Push(node T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	if q.isFull() {
+		return ErrFull
+	}
+
+	return q.push(node)
+}
+
+// PushWait adds node, waiting for space when the queue is at capacity. It returns ErrClosed once the queue is
+// closed, or the context error when ctx is done first.
+func (q *BlockingQueue[T]) PushWait(ctx context.Context, node T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+
+		if !q.isFull() {
+			err := q.push(node)
+			q.mu.Unlock()
+			return err
+		}
+
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Pop removes the top node without blocking, it returns nil when the queue is empty.
+func (q *BlockingQueue[T]) Pop() Node {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.heap.Len() == 0 {
+		return nil
+	}
+
+	return q.pop()
+}
+
+// PopWait removes the top node, waiting until one is available. Nodes left in a closed queue are still returned,
+// ErrClosed is returned once the closed queue is empty, or the context error when ctx is done first.
+func (q *BlockingQueue[T]) PopWait(ctx context.Context) (Node, error) {
+	for {
+		q.mu.Lock()
+		if q.heap.Len() > 0 {
+			node := q.pop()
+			q.mu.Unlock()
+			return node, nil
+		}
+
+		if q.closed {
+			q.mu.Unlock()
+			return nil, ErrClosed
+		}
+
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (q *BlockingQueue[T]) Update(id string, updates func(old T) T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.heap.Update(id, updates)
+}
+
+func (q *BlockingQueue[T]) Remove(id string) (Node, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	node, err := q.heap.Remove(id)
+	if err == nil {
+		q.notify()
+	}
+
+	return node, err
+}
+
+// Close rejects further pushes and wakes up all waiters, it is safe to call more than once.
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+	q.notify()
+}
+
+func (q *BlockingQueue[T]) isFull() bool {
+	return q.capacity > 0 && q.heap.Len() >= q.capacity
+}
+
+func (q *BlockingQueue[T]) push(node T) error {
+	if err := q.heap.Push(node); err != nil {
+		return err
+	}
+
+	q.notify()
+	return nil
+}
+
+func (q *BlockingQueue[T]) pop() Node {
+	node := q.heap.Pop()
+	q.notify()
+
+	return node
+}
+
+func (q *BlockingQueue[T]) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+func NewBlocking[T Node](d, capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		heap:     New(d, &[]T{}),
+		capacity: capacity,
+		changed:  make(chan struct{}),
+	}
+}
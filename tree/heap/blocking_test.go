@@ -0,0 +1,274 @@
+package heap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue_Push(t *testing.T) {
+	cases := map[string]struct {
+		capacity int
+		close    bool
+		items    []testItem
+		expected []error
+	}{
+		"push into unbounded queue": {
+			items: []testItem{
+				{Priority: 1, Value: "A"},
+				{Priority: 2, Value: "B"},
+				{Priority: 3, Value: "C"},
+			},
+			expected: []error{nil, nil, nil},
+		},
+		"push into full queue": {
+			capacity: 2,
+			items: []testItem{
+				{Priority: 1, Value: "A"},
+				{Priority: 2, Value: "B"},
+				{Priority: 3, Value: "C"},
+			},
+			expected: []error{nil, nil, ErrFull},
+		},
+		"push exists item": {
+			items: []testItem{
+				{Priority: 1, Value: "A"},
+				{Priority: 2, Value: "A"},
+			},
+			expected: []error{nil, errors.New("id A already exists")},
+		},
+		"push into closed queue": {
+			close: true,
+			items: []testItem{
+				{Priority: 1, Value: "A"},
+			},
+			expected: []error{ErrClosed},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			q := NewBlocking[testItem](2, tc.capacity)
+			if tc.close {
+				q.Close()
+			}
+
+			errs := make([]error, 0)
+			for _, item := range tc.items {
+				errs = append(errs, q.Push(item))
+			}
+
+			a.Equal(tc.expected, errs)
+		})
+	}
+}
+
+func TestBlockingQueue_PopWait(t *testing.T) {
+	cases := map[string]struct {
+		items    []testItem
+		push     *testItem
+		close    bool
+		timeout  time.Duration
+		expected Node
+		err      error
+	}{
+		"pop existing item": {
+			items:    []testItem{{Priority: 1, Value: "A"}, {Priority: 2, Value: "B"}},
+			timeout:  time.Second,
+			expected: testItem{Priority: 2, Value: "B"},
+		},
+		"wait for pushed item": {
+			push:     &testItem{Priority: 1, Value: "A"},
+			timeout:  time.Second,
+			expected: testItem{Priority: 1, Value: "A"},
+		},
+		"wait until context is done": {
+			timeout: 10 * time.Millisecond,
+			err:     context.DeadlineExceeded,
+		},
+		"wait until queue is closed": {
+			close:   true,
+			timeout: time.Second,
+			err:     ErrClosed,
+		},
+		"pop remaining item from closed queue": {
+			items:    []testItem{{Priority: 1, Value: "A"}},
+			close:    true,
+			timeout:  time.Second,
+			expected: testItem{Priority: 1, Value: "A"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			q := NewBlocking[testItem](2, 0)
+			for _, item := range tc.items {
+				_ = q.Push(item)
+			}
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				time.Sleep(time.Millisecond)
+				if tc.push != nil {
+					_ = q.Push(*tc.push)
+				}
+
+				if tc.close {
+					q.Close()
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			node, err := q.PopWait(ctx)
+			<-done
+
+			a.Equal(tc.expected, node)
+			a.Equal(tc.err, err)
+		})
+	}
+}
+
+func TestBlockingQueue_PushWait(t *testing.T) {
+	cases := map[string]struct {
+		pop      bool
+		remove   bool
+		close    bool
+		timeout  time.Duration
+		expected int
+		err      error
+	}{
+		"wait for pop": {
+			pop:      true,
+			timeout:  time.Second,
+			expected: 1,
+		},
+		"wait for remove": {
+			remove:   true,
+			timeout:  time.Second,
+			expected: 1,
+		},
+		"wait until context is done": {
+			timeout:  10 * time.Millisecond,
+			expected: 1,
+			err:      context.DeadlineExceeded,
+		},
+		"wait until queue is closed": {
+			close:    true,
+			timeout:  time.Second,
+			expected: 1,
+			err:      ErrClosed,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			q := NewBlocking[testItem](2, 1)
+			_ = q.Push(testItem{Priority: 1, Value: "A"})
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				time.Sleep(time.Millisecond)
+				if tc.pop {
+					q.Pop()
+				}
+
+				if tc.remove {
+					_, _ = q.Remove("A")
+				}
+
+				if tc.close {
+					q.Close()
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			err := q.PushWait(ctx, testItem{Priority: 2, Value: "B"})
+			<-done
+
+			a.Equal(tc.err, err)
+			a.Equal(tc.expected, q.Len())
+		})
+	}
+}
+
+func TestBlockingQueue_Concurrent(t *testing.T) {
+	cases := map[string]struct {
+		capacity  int
+		producers int
+		consumers int
+		items     int
+	}{
+		"bounded worker pool": {
+			capacity:  2,
+			producers: 3,
+			consumers: 4,
+			items:     50,
+		},
+		"unbounded worker pool": {
+			producers: 2,
+			consumers: 2,
+			items:     50,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			q := NewBlocking[testItem](4, tc.capacity)
+			ctx := context.Background()
+
+			var producers sync.WaitGroup
+			producers.Add(tc.producers)
+			for p := 0; p < tc.producers; p++ {
+				go func(p int) {
+					defer producers.Done()
+					for i := 0; i < tc.items; i++ {
+						a.Nil(q.PushWait(ctx, testItem{Priority: i, Value: fmt.Sprintf("%v-%v", p, i)}))
+					}
+				}(p)
+			}
+
+			var mu sync.Mutex
+			popped := make(map[string]bool)
+
+			var consumers sync.WaitGroup
+			consumers.Add(tc.consumers)
+			for c := 0; c < tc.consumers; c++ {
+				go func() {
+					defer consumers.Done()
+					for {
+						node, err := q.PopWait(ctx)
+						if err != nil {
+							a.Equal(ErrClosed, err)
+							return
+						}
+
+						mu.Lock()
+						popped[node.GetUniqueID()] = true
+						mu.Unlock()
+					}
+				}()
+			}
+
+			producers.Wait()
+			q.Close()
+			consumers.Wait()
+
+			a.Equal(tc.producers*tc.items, len(popped))
+			a.Equal(0, q.Len())
+		})
+	}
+}
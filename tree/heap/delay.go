@@ -0,0 +1,255 @@
+package heap
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers to DelayQueue, it can be replaced in tests.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type delayItem[T any] struct {
+	id    string
+	due   time.Time
+	seq   uint64
+	value T
+}
+
+func (i delayItem[T]) GetUniqueID() string {
+	return i.id
+}
+
+func (i delayItem[T]) Less(data Node) bool {
+	other := data.(delayItem[T])
+	return i.due.Before(other.due) || i.due.Equal(other.due) && i.seq < other.seq
+}
+
+// DelayQueue delivers values to the Ready channel once their due time is reached, values with the same due time in
+// the order they were pushed. It keeps a single timer for the earliest due time, and re-arms it when an earlier value
+// is pushed or updated. A due value stays in the queue until it is received from Ready, so it can be cancelled or
+// updated while the receiver is busy.
+type DelayQueue[T any] struct {
+	heap  *DHeap[delayItem[T]]
+	clock Clock
+	seq   uint64
+
+	// lock is held by the sending goroutine while it offers a due value, which is popped only when the send wins.
+	// Callers take lock directly, or over yield from the sending goroutine, so a value is never delivered after
+	// Cancel or Update has seen it.
+	lock  chan struct{}
+	yield chan struct{}
+
+	ready  chan T
+	rearm  chan struct{}
+	done   chan struct{}
+	closed sync.Once
+}
+
+func (q *DelayQueue[T]) Len() int {
+	return q.heap.Len()
+}
+
+// Ready returns the channel of due values, it is closed after the queue is closed.
+func (q *DelayQueue[T]) Ready() <-chan T {
+	return q.ready
+}
+
+// Push adds value to be delivered at due, it fails with ErrClosed once the queue is closed.
+func (q *DelayQueue[T]) Push(id string, value T, due time.Time) error {
+	q.acquire()
+	defer q.release()
+
+	select {
+	case <-q.done:
+		return ErrClosed
+	default:
+	}
+
+	if err := q.heap.Push(delayItem[T]{id: id, due: due, seq: q.seq, value: value}); err != nil {
+		return err
+	}
+
+	q.seq++
+
+	if next, _ := q.heap.PeekItem(); next.id == id {
+		q.notify()
+	}
+
+	return nil
+}
+
+func (q *DelayQueue[T]) PushAfter(id string, value T, delay time.Duration) error {
+	return q.Push(id, value, q.clock.Now().Add(delay))
+}
+
+// Update changes the due time of a value which has not been delivered yet.
+func (q *DelayQueue[T]) Update(id string, due time.Time) error {
+	q.acquire()
+	defer q.release()
+
+	err := q.heap.Update(id, func(old delayItem[T]) delayItem[T] {
+		return delayItem[T]{id: id, due: due, seq: old.seq, value: old.value}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	q.notify()
+	return nil
+}
+
+// Cancel removes a value which has not been delivered yet.
+func (q *DelayQueue[T]) Cancel(id string) (T, error) {
+	q.acquire()
+	defer q.release()
+
+	item, ok := q.heap.RemoveItem(id)
+	if !ok {
+		return item.value, itemNotExistsError(id)
+	}
+
+	q.notify()
+	return item.value, nil
+}
+
+// Close stops the delivery and closes the Ready channel, values not yet delivered are dropped.
+func (q *DelayQueue[T]) Close() {
+	q.closed.Do(func() {
+		close(q.done)
+	})
+}
+
+func (q *DelayQueue[T]) run() {
+	defer close(q.ready)
+
+	timer := q.clock.NewTimer(math.MaxInt64)
+	defer timer.Stop()
+
+	for {
+		select {
+		case q.lock <- struct{}{}:
+		case <-q.done:
+			return
+		}
+
+		wait, ok := q.offer()
+		if ok {
+			continue
+		}
+
+		q.release()
+		stopTimer(timer)
+		if wait > 0 {
+			timer.Reset(wait)
+		}
+
+		select {
+		case <-timer.C():
+		case <-q.rearm:
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// offer sends the earliest value when it is due and pops it once received, it is called holding lock. It returns
+// true when the value was received or lock was handed to a caller over yield, lock is then no longer held. Otherwise
+// it returns the time to wait, 0 for an empty queue.
+func (q *DelayQueue[T]) offer() (wait time.Duration, ok bool) {
+	next, ok := q.heap.PeekItem()
+	if !ok {
+		return 0, false
+	}
+
+	if wait = next.due.Sub(q.clock.Now()); wait > 0 {
+		return wait, false
+	}
+
+	select {
+	case q.ready <- next.value:
+		q.heap.Pop()
+		q.release()
+	case <-q.yield:
+	case <-q.done:
+		q.release()
+	}
+
+	return 0, true
+}
+
+// acquire takes lock, from the sending goroutine when it is waiting for the value to be received.
+func (q *DelayQueue[T]) acquire() {
+	select {
+	case q.lock <- struct{}{}:
+	case q.yield <- struct{}{}:
+	}
+}
+
+func (q *DelayQueue[T]) release() {
+	<-q.lock
+}
+
+func (q *DelayQueue[T]) notify() {
+	select {
+	case q.rearm <- struct{}{}:
+	default:
+	}
+}
+
+func stopTimer(t Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C():
+		default:
+		}
+	}
+}
+
+// NewDelay creates a DelayQueue using the system clock when clock is nil, call Close to release its goroutine.
+func NewDelay[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	q := &DelayQueue[T]{
+		heap:  New(2, &[]delayItem[T]{}),
+		clock: clock,
+		ready: make(chan T),
+		rearm: make(chan struct{}, 1),
+		lock:  make(chan struct{}, 1),
+		yield: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	go q.run()
+	return q
+}
@@ -0,0 +1,331 @@
+package heap
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDelayQueue(t *testing.T) {
+	type push struct {
+		id    string
+		delay time.Duration
+	}
+
+	cases := map[string]struct {
+		pushes   []push
+		update   map[string]time.Duration
+		cancel   []string
+		advance  []time.Duration
+		expected [][]string
+		err      error
+	}{
+		"deliver by due time": {
+			pushes: []push{
+				{id: "A", delay: 3 * time.Second},
+				{id: "B", delay: time.Second},
+				{id: "C", delay: 2 * time.Second},
+			},
+			advance:  []time.Duration{time.Second, time.Second, time.Second},
+			expected: [][]string{{"B"}, {"C"}, {"A"}},
+		},
+		"deliver all due items": {
+			pushes: []push{
+				{id: "A", delay: time.Second},
+				{id: "B", delay: 2 * time.Second},
+				{id: "C", delay: 5 * time.Second},
+			},
+			advance:  []time.Duration{2 * time.Second, 3 * time.Second},
+			expected: [][]string{{"A", "B"}, {"C"}},
+		},
+		"update to an earlier due time": {
+			pushes: []push{
+				{id: "A", delay: 5 * time.Second},
+				{id: "B", delay: 3 * time.Second},
+			},
+			update:   map[string]time.Duration{"A": time.Second},
+			advance:  []time.Duration{time.Second, 2 * time.Second},
+			expected: [][]string{{"A"}, {"B"}},
+		},
+		"update to a later due time": {
+			pushes: []push{
+				{id: "A", delay: time.Second},
+				{id: "B", delay: 2 * time.Second},
+			},
+			update:   map[string]time.Duration{"A": 3 * time.Second},
+			advance:  []time.Duration{2 * time.Second, time.Second},
+			expected: [][]string{{"B"}, {"A"}},
+		},
+		"deliver equal due times in push order": {
+			pushes: []push{
+				{id: "A", delay: time.Second},
+				{id: "B", delay: time.Second},
+				{id: "C", delay: time.Second},
+				{id: "D", delay: time.Second},
+				{id: "E", delay: time.Second},
+				{id: "F", delay: time.Second},
+				{id: "G", delay: time.Second},
+			},
+			advance:  []time.Duration{time.Second},
+			expected: [][]string{{"A", "B", "C", "D", "E", "F", "G"}},
+		},
+		"cancel item": {
+			pushes: []push{
+				{id: "A", delay: time.Second},
+				{id: "B", delay: 2 * time.Second},
+			},
+			cancel:   []string{"A"},
+			advance:  []time.Duration{time.Second, time.Second},
+			expected: [][]string{{}, {"B"}},
+		},
+		"cancel item not found": {
+			cancel: []string{"A"},
+			err:    errors.New("id A not found"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			clock := newFakeClock()
+			q := NewDelay[string](clock)
+			defer q.Close()
+
+			for _, p := range tc.pushes {
+				a.Nil(q.PushAfter(p.id, p.id, p.delay))
+			}
+
+			for id, delay := range tc.update {
+				a.Nil(q.Update(id, clock.Now().Add(delay)))
+			}
+
+			for _, id := range tc.cancel {
+				value, err := q.Cancel(id)
+				a.Equal(tc.err, err)
+				if err == nil {
+					a.Equal(id, value)
+				}
+			}
+
+			for i, d := range tc.advance {
+				clock.Advance(d)
+				a.Equal(tc.expected[i], receive(q, len(tc.expected[i])))
+			}
+
+			a.Equal(0, q.Len())
+		})
+	}
+}
+
+func TestDelayQueue_DueItemNotReceived(t *testing.T) {
+	cases := map[string]struct {
+		change   func(q *DelayQueue[string], clock *fakeClock) error
+		advance  time.Duration
+		expected []string
+	}{
+		"cancel due item": {
+			change: func(q *DelayQueue[string], _ *fakeClock) error {
+				if value, err := q.Cancel("A"); err != nil || value != "A" {
+					return errors.New("A not cancelled")
+				}
+
+				return nil
+			},
+			advance:  time.Second,
+			expected: []string{"B"},
+		},
+		"update due item": {
+			change: func(q *DelayQueue[string], clock *fakeClock) error {
+				return q.Update("A", clock.Now().Add(2*time.Second))
+			},
+			advance:  time.Second,
+			expected: []string{"B"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			clock := newFakeClock()
+			q := NewDelay[string](clock)
+			defer q.Close()
+
+			a.Nil(q.PushAfter("A", "A", 0))
+			a.Nil(q.PushAfter("B", "B", time.Second))
+
+			// wait for A to be offered on Ready before changing it.
+			time.Sleep(50 * time.Millisecond)
+			a.Nil(tc.change(q, clock))
+
+			clock.Advance(tc.advance)
+			a.Equal(tc.expected, receive(q, len(tc.expected)))
+		})
+	}
+}
+
+func TestDelayQueue_Close(t *testing.T) {
+	cases := map[string]struct {
+		pushes []string
+	}{
+		"close empty queue": {},
+		"close queue with pending items": {
+			pushes: []string{"A", "B"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			q := NewDelay[string](newFakeClock())
+			for _, id := range tc.pushes {
+				_ = q.PushAfter(id, id, time.Second)
+			}
+
+			q.Close()
+			q.Close()
+
+			_, ok := <-q.Ready()
+			a.False(ok)
+			a.Equal(ErrClosed, q.Push("C", "C", time.Now()))
+			a.Equal(ErrClosed, q.PushAfter("D", "D", time.Second))
+			a.Equal(len(tc.pushes), q.Len())
+		})
+	}
+}
+
+func TestDelayQueue_SystemClock(t *testing.T) {
+	cases := map[string]struct {
+		delays   map[string]time.Duration
+		expected []string
+	}{
+		"deliver with system clock": {
+			delays: map[string]time.Duration{
+				"A": 20 * time.Millisecond,
+				"B": 0,
+				"C": 10 * time.Millisecond,
+			},
+			expected: []string{"B", "C", "A"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			q := NewDelay[string](nil)
+			defer q.Close()
+
+			now := time.Now()
+			for id, delay := range tc.delays {
+				_ = q.Push(id, id, now.Add(delay))
+			}
+
+			a.Equal(tc.expected, receive(q, len(tc.expected)))
+		})
+	}
+}
+
+// receive waits for n values, then makes sure nothing else is delivered.
+func receive(q *DelayQueue[string], n int) []string {
+	values := make([]string, 0)
+	for len(values) < n {
+		select {
+		case v := <-q.Ready():
+			values = append(values, v)
+		case <-time.After(time.Second):
+			return values
+		}
+	}
+
+	select {
+	case v := <-q.Ready():
+		values = append(values, v)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	return values
+}
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	t.Reset(d)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		t.fire(c.now)
+	}
+}
+
+type fakeTimer struct {
+	clock  *fakeClock
+	c      chan time.Time
+	at     time.Time
+	active bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.active
+	t.active = false
+
+	return active
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.active
+	t.at = t.clock.now.Add(d)
+	t.active = true
+	t.fire(t.clock.now)
+
+	return active
+}
+
+func (t *fakeTimer) fire(now time.Time) {
+	if !t.active || t.at.After(now) {
+		return
+	}
+
+	t.active = false
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
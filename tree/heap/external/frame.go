@@ -0,0 +1,58 @@
+package external
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+const (
+	frameHeaderSize = 8
+	maxFrameSize    = 1 << 30
+)
+
+var (
+	errCorrupt = errors.New("corrupt record")
+	crcTable   = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// appendFrame appends payload with a header of its length and CRC, so a torn or corrupt write is detected on read.
+func appendFrame(buf, payload []byte) []byte {
+	var header [frameHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.Checksum(payload, crcTable))
+
+	return append(append(buf, header[:]...), payload...)
+}
+
+// readFrame returns io.EOF at a clean end, io.ErrUnexpectedEOF for a torn frame and errCorrupt for a CRC mismatch or
+// a length above maxFrameSize. The payload is read without trusting the length, so a corrupt length cannot allocate
+// more than the bytes left in r.
+func readFrame(r *bufio.Reader) ([]byte, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.LittleEndian.Uint32(header[:4])
+	if size > maxFrameSize {
+		return nil, errCorrupt
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) < int(size) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(header[4:]) {
+		return nil, errCorrupt
+	}
+
+	return payload, nil
+}
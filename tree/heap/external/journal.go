@@ -0,0 +1,248 @@
+package external
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/CameronXie/algorithms-go/sort/merge"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const journalName = "journal"
+
+const (
+	recordPush byte = iota + 1
+	recordPop
+	recordSpill
+	recordSeq
+	recordPopped
+	recordMerge
+)
+
+// record is a journal entry. A push record holds the seq and the encoded item, a pop record holds the run the item
+// was popped from, 0 for memory, and its seq. A spill record moves all items in memory to run, and holds the number
+// of items in seq. A seq record holds the next seq and the next run to use. A popped record holds the number of items
+// already popped from run, it replaces their pop records when the journal is compacted. A merge record replaces the
+// runs listed in data with run, and holds the number of items in seq.
+type record struct {
+	kind byte
+	run  uint64
+	seq  uint64
+	data []byte
+}
+
+func (r record) marshal() []byte {
+	buf := make([]byte, 1, 1+2*binary.MaxVarintLen64+len(r.data))
+	buf[0] = r.kind
+	buf = binary.AppendUvarint(buf, r.run)
+	buf = binary.AppendUvarint(buf, r.seq)
+
+	return append(buf, r.data...)
+}
+
+func unmarshalRecord(payload []byte) (record, error) {
+	if len(payload) == 0 {
+		return record{}, errCorrupt
+	}
+
+	r := record{kind: payload[0]}
+	payload = payload[1:]
+
+	for _, field := range []*uint64{&r.run, &r.seq} {
+		v, n := binary.Uvarint(payload)
+		if n <= 0 {
+			return record{}, errCorrupt
+		}
+
+		*field = v
+		payload = payload[n:]
+	}
+
+	r.data = payload
+	return r, nil
+}
+
+// journalFile is the file a journal appends to, it is an *os.File.
+type journalFile interface {
+	io.Writer
+	Truncate(size int64) error
+	Sync() error
+	Close() error
+}
+
+type journal struct {
+	file journalFile
+	size int64
+	sync bool
+	err  error
+
+	// snapshotSize is the size of the snapshot the journal was written with, before any append.
+	snapshotSize int64
+}
+
+// append writes records at the end of the journal. A failed write is truncated back to the last complete record, as
+// recovery stops at a torn record and would drop every record appended after it. When the journal cannot be truncated
+// it is failed, and every later append returns the error.
+func (j *journal) append(records ...record) error {
+	if j.err != nil {
+		return j.err
+	}
+
+	buf := make([]byte, 0)
+	for _, r := range records {
+		buf = appendFrame(buf, r.marshal())
+	}
+
+	n, err := j.file.Write(buf)
+	if err == nil && j.sync {
+		err = j.file.Sync()
+	}
+
+	if err != nil {
+		if truncErr := j.file.Truncate(j.size); truncErr != nil {
+			j.err = journalFailedError(err)
+		}
+
+		return err
+	}
+
+	j.size += int64(n)
+	return nil
+}
+
+func (j *journal) close() error {
+	return j.file.Close()
+}
+
+// readJournal returns the records in the journal of dir, stopping at the first torn or corrupt frame, which is what
+// a crash in the middle of an append leaves behind.
+func readJournal(dir string) ([]record, error) {
+	f, err := os.Open(filepath.Join(dir, journalName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := make([]record, 0)
+	r := bufio.NewReader(f)
+	for {
+		payload, err := readFrame(r)
+		if err == io.EOF || err == io.ErrUnexpectedEOF || err == errCorrupt {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		rec, err := unmarshalRecord(payload)
+		if err != nil {
+			return records, nil
+		}
+
+		records = append(records, rec)
+	}
+}
+
+// writeJournal replaces the journal of dir with records, it is written to a temporary file first and renamed, so a
+// crash leaves either the old or the new journal.
+func writeJournal(dir string, records []record, sync bool) (*journal, error) {
+	tmp := filepath.Join(dir, journalName+".tmp")
+	if err := writeFile(tmp, merge.FromSlice(records)); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, journalName)
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+
+	if err := syncDir(dir); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return &journal{file: f, size: info.Size(), sync: sync, snapshotSize: info.Size()}, nil
+}
+
+func writeFile(path string, records merge.Source[record]) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for r, ok := records(); ok; r, ok = records() {
+		if _, err := w.Write(appendFrame(nil, r.marshal())); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func marshalRuns(runs []uint64) []byte {
+	buf := make([]byte, 0, len(runs)*binary.MaxVarintLen64)
+	for _, run := range runs {
+		buf = binary.AppendUvarint(buf, run)
+	}
+
+	return buf
+}
+
+func unmarshalRuns(data []byte) ([]uint64, error) {
+	runs := make([]uint64, 0)
+	for len(data) > 0 {
+		run, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errCorrupt
+		}
+
+		runs = append(runs, run)
+		data = data[n:]
+	}
+
+	return runs, nil
+}
+
+func journalFailedError(err error) error {
+	return fmt.Errorf(`journal failed: %w`, err)
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
@@ -0,0 +1,187 @@
+package external
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecord_Marshal(t *testing.T) {
+	cases := map[string]struct {
+		record record
+	}{
+		"push record": {
+			record: record{kind: recordPush, seq: 300, data: []byte(`{"ID":"A"}`)},
+		},
+		"pop record": {
+			record: record{kind: recordPop, run: 7, seq: 1 << 40, data: []byte{}},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			r, err := unmarshalRecord(tc.record.marshal())
+			a.Nil(err)
+			a.Equal(tc.record, r)
+		})
+	}
+}
+
+func TestReadJournal(t *testing.T) {
+	records := []record{
+		{kind: recordSeq, seq: 3, data: []byte{}},
+		{kind: recordPush, seq: 1, data: []byte("A")},
+		{kind: recordPush, seq: 2, data: []byte("B")},
+	}
+
+	cases := map[string]struct {
+		data     func(data []byte) []byte
+		expected []record
+	}{
+		"read all records": {
+			data: func(data []byte) []byte {
+				return data
+			},
+			expected: records,
+		},
+		"stop at torn record": {
+			data: func(data []byte) []byte {
+				return data[:len(data)-1]
+			},
+			expected: records[:2],
+		},
+		"stop at corrupt record": {
+			data: func(data []byte) []byte {
+				data[frameHeaderSize]++
+				return data
+			},
+			expected: []record{},
+		},
+		"stop at oversized length": {
+			data: func(data []byte) []byte {
+				var header [frameHeaderSize]byte
+				binary.LittleEndian.PutUint32(header[:4], 1<<32-1)
+				return append(data, header[:]...)
+			},
+			expected: records,
+		},
+		"stop at length beyond the end": {
+			data: func(data []byte) []byte {
+				var header [frameHeaderSize]byte
+				binary.LittleEndian.PutUint32(header[:4], 1<<20)
+				return append(append(data, header[:]...), 'A')
+			},
+			expected: records,
+		},
+		"stop at empty record": {
+			data: func(data []byte) []byte {
+				return appendFrame(data, []byte{})
+			},
+			expected: records,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+
+			data := make([]byte, 0)
+			for _, r := range records {
+				data = appendFrame(data, r.marshal())
+			}
+			a.Nil(os.WriteFile(filepath.Join(dir, journalName), tc.data(data), 0o644))
+
+			read, err := readJournal(dir)
+			a.Nil(err)
+			a.Equal(tc.expected, read)
+		})
+	}
+}
+
+func TestJournal_Append(t *testing.T) {
+	errNoSpace := errors.New("no space left on device")
+	records := []record{
+		{kind: recordPush, seq: 1, data: []byte("A")},
+		{kind: recordPush, seq: 2, data: []byte("B")},
+		{kind: recordPush, seq: 3, data: []byte("C")},
+	}
+
+	cases := map[string]struct {
+		truncateErr error
+		expected    []record
+		expectedErr error
+	}{
+		"truncate failed write": {
+			expected: []record{records[0], records[2]},
+		},
+		"fail journal when truncate fails": {
+			truncateErr: errors.New("truncate failed"),
+			expected:    records[:1],
+			expectedErr: errNoSpace,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+
+			j, err := writeJournal(dir, []record{}, false)
+			a.Nil(err)
+			f := &faultyFile{File: j.file.(*os.File)}
+			j.file = f
+
+			a.Nil(j.append(records[0]))
+
+			f.writeErr = errNoSpace
+			f.truncateErr = tc.truncateErr
+			a.Equal(errNoSpace, j.append(records[1]))
+
+			f.writeErr = nil
+			a.True(errors.Is(j.append(records[2]), tc.expectedErr))
+			a.Nil(j.close())
+
+			read, err := readJournal(dir)
+			a.Nil(err)
+			a.Equal(tc.expected, read)
+		})
+	}
+}
+
+func TestReadJournal_NotExist(t *testing.T) {
+	a := assert.New(t)
+
+	records, err := readJournal(t.TempDir())
+	a.Nil(err)
+	a.Empty(records)
+}
+
+// faultyFile writes half of the data and returns writeErr when it is set, like a write running out of space.
+type faultyFile struct {
+	*os.File
+	writeErr    error
+	truncateErr error
+}
+
+func (f *faultyFile) Write(p []byte) (int, error) {
+	if f.writeErr == nil {
+		return f.File.Write(p)
+	}
+
+	n, _ := f.File.Write(p[:len(p)/2])
+	return n, f.writeErr
+}
+
+func (f *faultyFile) Truncate(size int64) error {
+	if f.truncateErr != nil {
+		return f.truncateErr
+	}
+
+	return f.File.Truncate(size)
+}
@@ -0,0 +1,716 @@
+package external
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/CameronXie/algorithms-go/sort/merge"
+	"github.com/CameronXie/algorithms-go/tree/heap"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	defaultMemoryLimit = 4096
+	defaultFanIn       = 64
+
+	// minCompactSize is the size the journal may grow by over its last snapshot before it is compacted by a push or
+	// pop, so small queues are not compacted on every few operations.
+	minCompactSize = 64 << 10
+)
+
+var ErrClosed = errors.New("queue closed")
+
+type Options struct {
+	// MemoryLimit is the number of items kept in memory before they are spilled to a sorted run, 4096 by default.
+	MemoryLimit int
+
+	// FanIn is the maximum number of runs, 64 by default, it must be at least 2. Every run keeps a file open, once
+	// there are more the oldest runs are merged into one.
+	FanIn int
+
+	// Sync calls fsync after every journal write. Without it every write still reaches the OS before returning, which
+	// survives a crash of the process but not of the machine.
+	Sync bool
+}
+
+type entry[T any] struct {
+	seq  uint64
+	item T
+}
+
+type head[T any] struct {
+	run   uint64
+	entry entry[T]
+	data  []byte
+}
+
+// Queue is a disk-backed priority queue. Items are kept in an in-memory heap until it reaches the memory limit, then
+// the heap is spilled to a sorted run file. Pop takes the top of the in-memory heap or the heads of the runs, which
+// are merged lazily through a heap of one item per run. Equal items are popped in the order they were pushed.
+//
+// Every push, pop and spill is appended to a journal in dir, and Open recovers the queue from it. The journal is
+// compacted on Open, after every spill, and by a push or pop once it has grown past twice its last snapshot, so its
+// size stays proportional to the items in the queue.
+type Queue[T any] struct {
+	dir   string
+	codec Codec[T]
+	opts  Options
+	less  func(a, b entry[T]) bool
+
+	mem     *heap.FuncHeap[entry[T], uint64]
+	heads   *heap.FuncHeap[head[T], uint64]
+	runs    map[uint64]*runReader
+	journal *journal
+
+	length  int
+	nextSeq uint64
+	nextRun uint64
+	closed  bool
+	mu      sync.Mutex
+}
+
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.length
+}
+
+func (q *Queue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	top, _, ok := q.top()
+	return top.item, ok
+}
+
+func (q *Queue[T]) Push(item T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	data, err := q.codec.Encode(item)
+	if err != nil {
+		return err
+	}
+
+	if len(data) > maxFrameSize-1-2*binary.MaxVarintLen64 {
+		return itemTooLargeError(len(data))
+	}
+
+	if q.mem.Len() >= q.opts.MemoryLimit {
+		if err := q.spill(); err != nil {
+			return err
+		}
+	} else if err := q.compactIfGrown(); err != nil {
+		return err
+	}
+
+	seq := q.nextSeq
+	if err := q.journal.append(record{kind: recordPush, seq: seq, data: data}); err != nil {
+		return err
+	}
+
+	q.nextSeq++
+	q.length++
+	_ = q.mem.Push(entry[T]{seq: seq, item: item})
+
+	return nil
+}
+
+// Pop removes and returns the top item, it returns false when the queue is empty.
+func (q *Queue[T]) Pop() (T, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var empty T
+	if q.closed {
+		return empty, false, ErrClosed
+	}
+
+	top, fromRun, ok := q.top()
+	if !ok {
+		return empty, false, nil
+	}
+
+	if err := q.compactIfGrown(); err != nil {
+		return empty, false, err
+	}
+
+	if !fromRun {
+		if err := q.journal.append(record{kind: recordPop, seq: top.seq}); err != nil {
+			return empty, false, err
+		}
+
+		q.mem.Pop()
+		q.length--
+
+		return top.item, true, nil
+	}
+
+	h, _ := q.heads.Peek()
+	next, eof, err := q.nextHead(h.run)
+	if err != nil {
+		return empty, false, err
+	}
+
+	if err := q.journal.append(record{kind: recordPop, run: h.run, seq: top.seq}); err != nil {
+		return empty, false, err
+	}
+
+	q.runs[h.run].popped++
+	q.setHead(h.run, next, eof)
+	q.length--
+
+	return top.item, true, nil
+}
+
+// Close releases the files of the queue, the queue can be opened again from the same directory.
+func (q *Queue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil
+	}
+
+	q.closed = true
+	for _, r := range q.runs {
+		_ = r.close()
+	}
+
+	return q.journal.close()
+}
+
+func (q *Queue[T]) top() (top entry[T], fromRun bool, ok bool) {
+	memTop, memOK := q.mem.Peek()
+	h, headOK := q.heads.Peek()
+
+	switch {
+	case headOK && (!memOK || q.less(h.entry, memTop)):
+		return h.entry, true, true
+	case memOK:
+		return memTop, false, true
+	}
+
+	return top, false, false
+}
+
+// spill writes the items in memory to a new run in priority order, only the head of the run stays in memory. The
+// journal is compacted afterwards, as the push records of the spilled items are no longer needed.
+func (q *Queue[T]) spill() error {
+	entries := q.mem.ToSortedSlice()
+	records := make([]record, len(entries))
+	for i, e := range entries {
+		data, err := q.codec.Encode(e.item)
+		if err != nil {
+			return err
+		}
+
+		records[i] = record{kind: recordPush, seq: e.seq, data: data}
+	}
+
+	run := q.nextRun
+	if err := writeRun(q.dir, run, merge.FromSlice(records)); err != nil {
+		return err
+	}
+
+	reader, err := openRun(q.dir, run)
+	if err == nil {
+		err = q.journal.append(record{kind: recordSpill, run: run, seq: uint64(len(records))})
+		if err != nil {
+			_ = reader.close()
+		}
+	}
+
+	if err != nil {
+		_ = os.Remove(runPath(q.dir, run))
+		return err
+	}
+
+	q.nextRun++
+	q.runs[run] = reader
+	q.mem.Drain()
+
+	reader.count = uint64(len(records))
+	_, _ = reader.next()
+	_ = q.heads.Push(head[T]{run: run, entry: entries[0]})
+
+	if len(q.runs) > q.opts.FanIn {
+		if err := q.mergeRuns(); err != nil {
+			return err
+		}
+	}
+
+	return q.compact()
+}
+
+// mergeRuns merges the oldest runs into a new run, leaving half of the fan-in limit for the runs spilled next. The
+// runs are read again from the items not yet popped, so a failed merge leaves the queue as it was.
+func (q *Queue[T]) mergeRuns() error {
+	runs := q.runIDs()
+	runs = runs[:len(runs)-q.opts.FanIn/2]
+
+	var readErr error
+	sources := make([]merge.Source[head[T]], 0, len(runs))
+	for _, run := range runs {
+		reader, err := openRun(q.dir, run)
+		if err != nil {
+			return err
+		}
+		defer reader.close()
+
+		for i := uint64(0); i < q.runs[run].popped; i++ {
+			if _, err := reader.next(); err != nil {
+				return err
+			}
+		}
+
+		sources = append(sources, q.runSource(reader, &readErr))
+	}
+
+	merger := merge.NewMerger(func(a, b head[T]) bool {
+		return q.less(a.entry, b.entry)
+	}, merge.MergeOptions[head[T]]{}, sources...)
+
+	var count uint64
+	merged := q.nextRun
+	err := writeRun(q.dir, merged, func() (record, bool) {
+		h, ok := merger.Next()
+		if ok {
+			count++
+		}
+
+		return record{kind: recordPush, seq: h.entry.seq, data: h.data}, ok
+	})
+
+	if err == nil {
+		err = readErr
+	}
+
+	var reader *runReader
+	if err == nil {
+		reader, err = openRun(q.dir, merged)
+	}
+
+	if err == nil {
+		err = q.journal.append(record{kind: recordMerge, run: merged, seq: count, data: marshalRuns(runs)})
+		if err != nil {
+			_ = reader.close()
+		}
+	}
+
+	if err != nil {
+		_ = os.Remove(runPath(q.dir, merged))
+		return err
+	}
+
+	q.nextRun++
+	for _, run := range runs {
+		_ = q.runs[run].close()
+		delete(q.runs, run)
+		q.heads.Remove(run)
+		_ = os.Remove(runPath(q.dir, run))
+	}
+
+	reader.count = count
+	q.runs[merged] = reader
+	h, _, err := q.nextHead(merged)
+	if err != nil {
+		return err
+	}
+
+	q.setHead(merged, h, false)
+	return nil
+}
+
+// runSource returns the items of reader with their encoded data, a read error is kept in err and ends the source.
+func (q *Queue[T]) runSource(reader *runReader, err *error) merge.Source[head[T]] {
+	return func() (head[T], bool) {
+		var h head[T]
+		if *err != nil {
+			return h, false
+		}
+
+		rec, readErr := reader.next()
+		if readErr == io.EOF {
+			return h, false
+		}
+
+		if readErr == nil {
+			h.entry.item, readErr = q.codec.Decode(rec.data)
+		}
+
+		if readErr != nil {
+			*err = readErr
+			return h, false
+		}
+
+		h.entry.seq = rec.seq
+		h.data = rec.data
+
+		return h, true
+	}
+}
+
+// compact replaces the journal with a snapshot of the queue. The journal is failed when it cannot be replaced, as the
+// journal on disk may already be the new one.
+func (q *Queue[T]) compact() error {
+	records, err := q.snapshot()
+	if err != nil {
+		return err
+	}
+
+	j, err := writeJournal(q.dir, records, q.opts.Sync)
+	if err != nil {
+		q.journal.err = journalFailedError(err)
+		return err
+	}
+
+	_ = q.journal.close()
+	q.journal = j
+
+	return nil
+}
+
+// compactIfGrown compacts the journal when the records appended since its last snapshot outgrow the snapshot.
+func (q *Queue[T]) compactIfGrown() error {
+	if q.journal.size <= 2*q.journal.snapshotSize+minCompactSize {
+		return nil
+	}
+
+	return q.compact()
+}
+
+// snapshot returns the records of a compacted journal, which only keeps the items still in memory and the runs not
+// yet fully popped.
+func (q *Queue[T]) snapshot() ([]record, error) {
+	records := []record{{kind: recordSeq, run: q.nextRun, seq: q.nextSeq}}
+	for _, run := range q.runIDs() {
+		r := q.runs[run]
+		records = append(records, record{kind: recordSpill, run: run, seq: r.count})
+		if r.popped > 0 {
+			records = append(records, record{kind: recordPopped, run: run, seq: r.popped})
+		}
+	}
+
+	entries := q.mem.Items()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+
+	for _, e := range entries {
+		data, err := q.codec.Encode(e.item)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record{kind: recordPush, seq: e.seq, data: data})
+	}
+
+	return records, nil
+}
+
+// nextHead returns the item after the current head of run without consuming it, eof is true when there is none.
+func (q *Queue[T]) nextHead(run uint64) (h head[T], eof bool, err error) {
+	rec, err := q.runs[run].peek()
+	if err == io.EOF {
+		return h, true, nil
+	}
+
+	if err != nil {
+		return h, false, err
+	}
+
+	item, err := q.codec.Decode(rec.data)
+	if err != nil {
+		return h, false, err
+	}
+
+	return head[T]{run: run, entry: entry[T]{seq: rec.seq, item: item}}, false, nil
+}
+
+// setHead consumes the next head of run returned by nextHead, a fully read run is closed and removed.
+func (q *Queue[T]) setHead(run uint64, h head[T], eof bool) {
+	reader := q.runs[run]
+	if eof {
+		q.heads.Remove(run)
+		delete(q.runs, run)
+		_ = reader.close()
+		_ = os.Remove(runPath(q.dir, run))
+
+		return
+	}
+
+	_, _ = reader.next()
+	if q.heads.Update(run, func(head[T]) head[T] {
+		return h
+	}) != nil {
+		_ = q.heads.Push(h)
+	}
+}
+
+// runIDs returns the runs from the oldest to the newest.
+func (q *Queue[T]) runIDs() []uint64 {
+	runs := make([]uint64, 0, len(q.runs))
+	for run := range q.runs {
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i] < runs[j]
+	})
+
+	return runs
+}
+
+// recover replays the journal records, opening the runs not yet fully popped and pushing the items still in memory.
+func (q *Queue[T]) recover(records []record) error {
+	mem := make(map[uint64][]byte)
+	spills := make([]record, 0)
+	popped := make(map[uint64]uint64)
+
+	for _, r := range records {
+		switch r.kind {
+		case recordSeq:
+			q.nextSeq = maxUint64(q.nextSeq, r.seq)
+			q.nextRun = maxUint64(q.nextRun, r.run)
+		case recordPush:
+			mem[r.seq] = r.data
+			q.nextSeq = maxUint64(q.nextSeq, r.seq+1)
+		case recordPop:
+			if r.run == 0 {
+				delete(mem, r.seq)
+			} else {
+				popped[r.run]++
+			}
+		case recordPopped:
+			popped[r.run] = r.seq
+		case recordSpill:
+			mem = make(map[uint64][]byte)
+			spills = append(spills, r)
+			q.nextRun = maxUint64(q.nextRun, r.run+1)
+		case recordMerge:
+			runs, err := unmarshalRuns(r.data)
+			if err != nil {
+				return err
+			}
+
+			spills = removeRuns(spills, runs)
+			for _, run := range runs {
+				delete(popped, run)
+			}
+
+			spills = append(spills, record{kind: recordSpill, run: r.run, seq: r.seq})
+			q.nextRun = maxUint64(q.nextRun, r.run+1)
+		}
+	}
+
+	for _, s := range spills {
+		skip := popped[s.run]
+		if skip >= s.seq {
+			continue
+		}
+
+		if err := q.recoverRun(s.run, s.seq, skip); err != nil {
+			return err
+		}
+
+		q.length += int(s.seq - skip)
+	}
+
+	seqs := make([]uint64, 0, len(mem))
+	for seq := range mem {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool {
+		return seqs[i] < seqs[j]
+	})
+
+	entries := make([]entry[T], len(seqs))
+	for i, seq := range seqs {
+		item, err := q.codec.Decode(mem[seq])
+		if err != nil {
+			return err
+		}
+
+		entries[i] = entry[T]{seq: seq, item: item}
+	}
+
+	q.length += len(entries)
+	return q.mem.PushMany(entries...)
+}
+
+// recoverRun opens run of count items and skips the items already popped.
+func (q *Queue[T]) recoverRun(run, count, skip uint64) error {
+	reader, err := openRun(q.dir, run)
+	if err != nil {
+		return err
+	}
+
+	reader.count = count
+	reader.popped = skip
+	q.runs[run] = reader
+	for i := uint64(0); i < skip; i++ {
+		if _, err := reader.next(); err != nil {
+			return runTooShortError(run, err)
+		}
+	}
+
+	h, eof, err := q.nextHead(run)
+	if eof {
+		err = runTooShortError(run, io.EOF)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	q.setHead(run, h, false)
+	return nil
+}
+
+// removeOrphans removes the temporary files and the runs which are not in the journal, left by a crash.
+func (q *Queue[T]) removeOrphans() error {
+	names, err := filepath.Glob(filepath.Join(q.dir, "*"))
+	if err != nil {
+		return err
+	}
+
+	live := map[string]bool{filepath.Join(q.dir, journalName): true}
+	for run := range q.runs {
+		live[runPath(q.dir, run)] = true
+	}
+
+	for _, name := range names {
+		if filepath.Ext(name) == ".tmp" || isRun(name) && !live[name] {
+			if err := os.Remove(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (q *Queue[T]) closeRuns() {
+	for _, r := range q.runs {
+		_ = r.close()
+	}
+}
+
+// removeRuns returns the spill records of spills which are not of runs.
+func removeRuns(spills []record, runs []uint64) []record {
+	removed := make(map[uint64]bool, len(runs))
+	for _, run := range runs {
+		removed[run] = true
+	}
+
+	kept := make([]record, 0, len(spills))
+	for _, s := range spills {
+		if !removed[s.run] {
+			kept = append(kept, s)
+		}
+	}
+
+	return kept
+}
+
+func isRun(name string) bool {
+	var run uint64
+	_, err := fmt.Sscanf(filepath.Base(name), "run-%d", &run)
+
+	return err == nil
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func itemTooLargeError(size int) error {
+	return fmt.Errorf(`encoded item of %v bytes is too large`, size)
+}
+
+func invalidFanInError(fanIn int) error {
+	return fmt.Errorf(`fan-in must be at least 2, got %v`, fanIn)
+}
+
+func runTooShortError(run uint64, err error) error {
+	return fmt.Errorf(`run %v has fewer items than the journal: %w`, run, err)
+}
+
+// Open opens the queue stored in dir, creating dir when it does not exist. less reports whether a should be popped
+// before b, and codec encodes the items written to disk.
+func Open[T any](dir string, codec Codec[T], less func(a, b T) bool, opts Options) (*Queue[T], error) {
+	if opts.MemoryLimit <= 0 {
+		opts.MemoryLimit = defaultMemoryLimit
+	}
+
+	if opts.FanIn == 0 {
+		opts.FanIn = defaultFanIn
+	}
+
+	if opts.FanIn < 2 {
+		return nil, invalidFanInError(opts.FanIn)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	records, err := readJournal(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Queue[T]{
+		dir:     dir,
+		codec:   codec,
+		opts:    opts,
+		runs:    make(map[uint64]*runReader),
+		nextRun: 1,
+		less: func(a, b entry[T]) bool {
+			return less(a.item, b.item) || !less(b.item, a.item) && a.seq < b.seq
+		},
+	}
+
+	q.mem = heap.NewFunc(2, []entry[T]{}, func(e entry[T]) uint64 {
+		return e.seq
+	}, q.less)
+
+	q.heads = heap.NewFunc(2, []head[T]{}, func(h head[T]) uint64 {
+		return h.run
+	}, func(a, b head[T]) bool {
+		return q.less(a.entry, b.entry)
+	})
+
+	err = q.recover(records)
+	if err == nil {
+		err = q.removeOrphans()
+	}
+
+	if err == nil {
+		records, err = q.snapshot()
+	}
+
+	if err == nil {
+		q.journal, err = writeJournal(dir, records, opts.Sync)
+	}
+
+	if err != nil {
+		q.closeRuns()
+		return nil, err
+	}
+
+	return q, nil
+}
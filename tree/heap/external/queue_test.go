@@ -0,0 +1,518 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestQueue_PushPop(t *testing.T) {
+	cases := map[string]struct {
+		memoryLimit int
+		items       []job
+		expected    []string
+	}{
+		"pop from memory": {
+			memoryLimit: 10,
+			items:       getTestJobs(),
+			expected:    []string{"B", "E", "G", "C", "F", "A", "D"},
+		},
+		"pop from runs": {
+			memoryLimit: 2,
+			items:       getTestJobs(),
+			expected:    []string{"B", "E", "G", "C", "F", "A", "D"},
+		},
+		"pop from single item runs": {
+			memoryLimit: 1,
+			items:       getTestJobs(),
+			expected:    []string{"B", "E", "G", "C", "F", "A", "D"},
+		},
+		"pop from empty queue": {
+			memoryLimit: 1,
+			items:       []job{},
+			expected:    []string{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			q := openTestQueue(t, t.TempDir(), tc.memoryLimit)
+			defer q.Close()
+
+			for _, item := range tc.items {
+				a.Nil(q.Push(item))
+			}
+
+			a.Equal(len(tc.items), q.Len())
+			a.Equal(tc.expected, popAll(t, q))
+			a.Equal(0, q.Len())
+
+			_, ok := q.Peek()
+			a.False(ok)
+		})
+	}
+}
+
+func TestQueue_Recover(t *testing.T) {
+	cases := map[string]struct {
+		memoryLimit int
+		pop         int
+		close       bool
+		expected    []string
+	}{
+		"reopen closed queue": {
+			memoryLimit: 2,
+			pop:         3,
+			close:       true,
+			expected:    []string{"C", "F", "A", "D"},
+		},
+		"reopen crashed queue": {
+			memoryLimit: 3,
+			pop:         2,
+			expected:    []string{"G", "C", "F", "A", "D"},
+		},
+		"reopen queue in memory": {
+			memoryLimit: 10,
+			pop:         1,
+			expected:    []string{"E", "G", "C", "F", "A", "D"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+			q := openTestQueue(t, dir, tc.memoryLimit)
+
+			for _, item := range getTestJobs() {
+				a.Nil(q.Push(item))
+			}
+
+			for i := 0; i < tc.pop; i++ {
+				_, _, err := q.Pop()
+				a.Nil(err)
+			}
+
+			if tc.close {
+				a.Nil(q.Close())
+			} else {
+				defer q.Close()
+			}
+
+			reopened := openTestQueue(t, dir, tc.memoryLimit)
+			defer reopened.Close()
+
+			a.Equal(len(tc.expected), reopened.Len())
+			top, ok := reopened.Peek()
+			a.True(ok)
+			a.Equal(tc.expected[0], top.ID)
+
+			a.Nil(reopened.Push(job{ID: "H", Priority: 1}))
+			a.Equal(append(tc.expected, "H"), popAll(t, reopened))
+			a.Equal([]string{journalName}, getFiles(t, dir))
+		})
+	}
+}
+
+func TestQueue_CorruptJournal(t *testing.T) {
+	cases := map[string]struct {
+		corrupt  func(data []byte) []byte
+		expected []string
+	}{
+		"torn record": {
+			corrupt: func(data []byte) []byte {
+				return append(data, 20, 0, 0, 0, 1, 2, 3)
+			},
+			expected: []string{"B", "E", "G", "C", "F", "A", "D"},
+		},
+		"corrupt last record": {
+			corrupt: func(data []byte) []byte {
+				data[len(data)-1]++
+				return data
+			},
+			expected: []string{"B", "E", "C", "F", "A", "D"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+			q := openTestQueue(t, dir, 3)
+			for _, item := range getTestJobs() {
+				a.Nil(q.Push(item))
+			}
+			a.Nil(q.Close())
+
+			path := filepath.Join(dir, journalName)
+			data, err := os.ReadFile(path)
+			a.Nil(err)
+			a.Nil(os.WriteFile(path, tc.corrupt(data), 0o644))
+
+			reopened := openTestQueue(t, dir, 3)
+			defer reopened.Close()
+
+			a.Equal(tc.expected, popAll(t, reopened))
+		})
+	}
+}
+
+func TestQueue_FailedWrite(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	errNoSpace := errors.New("no space left on device")
+	q := openTestQueue(t, dir, 10)
+
+	f := &faultyFile{File: q.journal.file.(*os.File)}
+	q.journal.file = f
+	jobs := getTestJobs()
+	a.Nil(q.Push(jobs[0]))
+
+	f.writeErr = errNoSpace
+	a.Equal(errNoSpace, q.Push(jobs[1]))
+	a.Equal(1, q.Len())
+
+	f.writeErr = nil
+	for _, item := range jobs[2:] {
+		a.Nil(q.Push(item))
+	}
+	a.Nil(q.Close())
+
+	reopened := openTestQueue(t, dir, 10)
+	defer reopened.Close()
+
+	a.Equal([]string{"E", "G", "C", "F", "A", "D"}, popAll(t, reopened))
+}
+
+func TestQueue_CompactJournal(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	q := openTestQueue(t, dir, 10)
+
+	expected := make([]string, 0)
+	for i := 0; i < 1000; i++ {
+		a.Nil(q.Push(job{ID: fmt.Sprint(i)}))
+		expected = append(expected, fmt.Sprint(i))
+	}
+
+	info, err := os.Stat(filepath.Join(dir, journalName))
+	a.Nil(err)
+	a.Less(info.Size(), int64(4096))
+
+	for i := 0; i < 500; i++ {
+		_, _, err := q.Pop()
+		a.Nil(err)
+	}
+	a.Nil(q.Close())
+
+	reopened := openTestQueue(t, dir, 10)
+	defer reopened.Close()
+
+	a.Equal(expected[500:], popAll(t, reopened))
+}
+
+func TestQueue_CompactGrownJournal(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	q := openTestQueue(t, dir, 100)
+
+	jobs := getTestJobs()
+	for _, item := range jobs {
+		a.Nil(q.Push(item))
+	}
+
+	for i := 0; i < 20000; i++ {
+		a.Nil(q.Push(job{ID: fmt.Sprint(i), Priority: 10}))
+		popped, ok, err := q.Pop()
+		a.Nil(err)
+		a.True(ok)
+		a.Equal(fmt.Sprint(i), popped.ID)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, journalName))
+	a.Nil(err)
+	a.Less(info.Size(), int64(2*minCompactSize))
+	a.Nil(q.Close())
+
+	reopened := openTestQueue(t, dir, 100)
+	defer reopened.Close()
+
+	a.Equal([]string{"B", "E", "G", "C", "F", "A", "D"}, popAll(t, reopened))
+}
+
+func TestQueue_FanIn(t *testing.T) {
+	cases := map[string]struct {
+		fanIn int
+		pop   int
+	}{
+		"merge runs": {
+			fanIn: 4,
+			pop:   100,
+		},
+		"merge runs at minimum fan-in": {
+			fanIn: 2,
+			pop:   250,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+			opts := Options{MemoryLimit: 1, FanIn: tc.fanIn}
+			q := openTestQueueWith(t, dir, opts)
+
+			expected := make([]job, 0)
+			for i := 0; i < 300; i++ {
+				item := job{ID: fmt.Sprint(i), Priority: rand.Intn(20)}
+				a.Nil(q.Push(item))
+				expected = append(expected, item)
+				a.LessOrEqual(len(getFiles(t, dir)), tc.fanIn+1)
+			}
+
+			sort.SliceStable(expected, func(i, j int) bool {
+				return jobLess(expected[i], expected[j])
+			})
+
+			for i := 0; i < tc.pop; i++ {
+				item, ok, err := q.Pop()
+				a.Nil(err)
+				a.True(ok)
+				a.Equal(expected[i], item)
+			}
+			a.Nil(q.Close())
+
+			reopened := openTestQueueWith(t, dir, opts)
+			defer reopened.Close()
+
+			ids := make([]string, 0)
+			for _, item := range expected[tc.pop:] {
+				ids = append(ids, item.ID)
+			}
+			a.Equal(ids, popAll(t, reopened))
+		})
+	}
+}
+
+func TestOpen_InvalidFanIn(t *testing.T) {
+	cases := map[string]struct {
+		fanIn    int
+		expected string
+	}{
+		"fan-in of 1": {
+			fanIn:    1,
+			expected: `fan-in must be at least 2, got 1`,
+		},
+		"negative fan-in": {
+			fanIn:    -1,
+			expected: `fan-in must be at least 2, got -1`,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+
+			_, err := Open[job](dir, JSONCodec[job]{}, jobLess, Options{FanIn: tc.fanIn})
+			a.EqualError(err, tc.expected)
+			a.Empty(getFiles(t, dir))
+		})
+	}
+}
+
+func TestQueue_Orphans(t *testing.T) {
+	cases := map[string]struct {
+		files []string
+	}{
+		"remove orphan files": {
+			files: []string{"run-99", "run-1.tmp", "journal.tmp"},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+			for _, f := range tc.files {
+				a.Nil(os.WriteFile(filepath.Join(dir, f), []byte("orphan"), 0o644))
+			}
+
+			q := openTestQueue(t, dir, 1)
+			defer q.Close()
+
+			a.Equal([]string{journalName}, getFiles(t, dir))
+		})
+	}
+}
+
+func TestQueue_MissingRun(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+	q := openTestQueue(t, dir, 2)
+	for _, item := range getTestJobs() {
+		a.Nil(q.Push(item))
+	}
+	a.Nil(q.Close())
+
+	a.Nil(os.Remove(runPath(dir, 1)))
+
+	_, err := Open[job](dir, JSONCodec[job]{}, jobLess, Options{MemoryLimit: 2})
+	a.True(errors.Is(err, os.ErrNotExist))
+}
+
+func TestQueue_Closed(t *testing.T) {
+	a := assert.New(t)
+	q := openTestQueue(t, t.TempDir(), 1)
+	a.Nil(q.Close())
+	a.Nil(q.Close())
+
+	a.Equal(ErrClosed, q.Push(job{ID: "A"}))
+	_, _, err := q.Pop()
+	a.Equal(ErrClosed, err)
+}
+
+func TestQueue_Random(t *testing.T) {
+	cases := map[string]struct {
+		memoryLimit int
+		fanIn       int
+		operations  int
+	}{
+		"small memory": {
+			memoryLimit: 4,
+			operations:  2000,
+		},
+		"small memory and fan-in": {
+			memoryLimit: 2,
+			fanIn:       3,
+			operations:  2000,
+		},
+		"large memory": {
+			memoryLimit: 64,
+			operations:  2000,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dir := t.TempDir()
+			opts := Options{MemoryLimit: tc.memoryLimit, FanIn: tc.fanIn}
+			q := openTestQueueWith(t, dir, opts)
+			expected := make([]job, 0)
+
+			for i := 0; i < tc.operations; i++ {
+				switch r := rand.Intn(10); {
+				case r < 6:
+					item := job{ID: fmt.Sprint(i), Priority: rand.Intn(20)}
+					a.Nil(q.Push(item))
+					expected = append(expected, item)
+				case r < 9:
+					item, ok, err := q.Pop()
+					a.Nil(err)
+					a.Equal(len(expected) > 0, ok)
+					if ok {
+						a.Equal(expected[0], item)
+						expected = expected[1:]
+					}
+				default:
+					a.Nil(q.Close())
+					q = openTestQueueWith(t, dir, opts)
+				}
+
+				sort.SliceStable(expected, func(i, j int) bool {
+					return jobLess(expected[i], expected[j])
+				})
+				a.Equal(len(expected), q.Len())
+			}
+
+			a.Nil(q.Close())
+		})
+	}
+}
+
+func BenchmarkQueue_PushPop(b *testing.B) {
+	q, err := Open[job](b.TempDir(), JSONCodec[job]{}, jobLess, Options{MemoryLimit: 1024})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer q.Close()
+
+	for i := 0; i < b.N; i++ {
+		_ = q.Push(job{ID: fmt.Sprint(i), Priority: rand.Intn(1000)})
+	}
+
+	for i := 0; i < b.N; i++ {
+		_, _, _ = q.Pop()
+	}
+}
+
+type job struct {
+	ID       string
+	Priority int
+}
+
+func jobLess(a, b job) bool {
+	return a.Priority > b.Priority
+}
+
+func getTestJobs() []job {
+	return []job{
+		{ID: "A", Priority: 2},
+		{ID: "B", Priority: 5},
+		{ID: "C", Priority: 3},
+		{ID: "D", Priority: 1},
+		{ID: "E", Priority: 4},
+		{ID: "F", Priority: 3},
+		{ID: "G", Priority: 4},
+	}
+}
+
+func openTestQueue(t *testing.T, dir string, memoryLimit int) *Queue[job] {
+	return openTestQueueWith(t, dir, Options{MemoryLimit: memoryLimit})
+}
+
+func openTestQueueWith(t *testing.T, dir string, opts Options) *Queue[job] {
+	q, err := Open[job](dir, JSONCodec[job]{}, jobLess, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return q
+}
+
+func popAll(t *testing.T, q *Queue[job]) []string {
+	ids := make([]string, 0)
+	for {
+		item, ok, err := q.Pop()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ok {
+			return ids
+		}
+
+		ids = append(ids, item.ID)
+	}
+}
+
+func getFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, 0)
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	return names
+}
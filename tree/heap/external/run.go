@@ -0,0 +1,87 @@
+package external
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/CameronXie/algorithms-go/sort/merge"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// runReader reads a sorted run one record at a time. The next record can be peeked before it is consumed, and an
+// error is kept so a corrupt run is never skipped over. count is the number of items in the run and popped the number
+// of them already popped.
+type runReader struct {
+	file    *os.File
+	r       *bufio.Reader
+	pending *record
+	err     error
+	count   uint64
+	popped  uint64
+}
+
+// peek returns the next record without consuming it, or io.EOF when the run is fully read.
+func (r *runReader) peek() (record, error) {
+	if r.pending != nil {
+		return *r.pending, nil
+	}
+
+	if r.err != nil {
+		return record{}, r.err
+	}
+
+	payload, err := readFrame(r.r)
+	if err == io.EOF {
+		return record{}, err
+	}
+
+	if err == nil {
+		var rec record
+		if rec, err = unmarshalRecord(payload); err == nil {
+			r.pending = &rec
+			return rec, nil
+		}
+	}
+
+	r.err = fmt.Errorf(`%v: %w`, r.file.Name(), err)
+	return record{}, r.err
+}
+
+func (r *runReader) next() (record, error) {
+	rec, err := r.peek()
+	r.pending = nil
+
+	return rec, err
+}
+
+func (r *runReader) close() error {
+	return r.file.Close()
+}
+
+func openRun(dir string, run uint64) (*runReader, error) {
+	f, err := os.Open(runPath(dir, run))
+	if err != nil {
+		return nil, err
+	}
+
+	return &runReader{file: f, r: bufio.NewReader(f)}, nil
+}
+
+// writeRun writes records to a temporary file and renames it, so a run file is always complete.
+func writeRun(dir string, run uint64, records merge.Source[record]) error {
+	path := runPath(dir, run)
+	if err := writeFile(path+".tmp", records); err != nil {
+		return err
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+func runPath(dir string, run uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("run-%d", run))
+}
@@ -0,0 +1,300 @@
+package heap
+
+import (
+	"math/bits"
+	"sync"
+)
+
+type fibonacciNode[T Node] struct {
+	data   T
+	degree int
+	marked bool
+
+	parent *fibonacciNode[T]
+	child  *fibonacciNode[T]
+	left   *fibonacciNode[T]
+	right  *fibonacciNode[T]
+}
+
+// FibonacciHeap is a mergeable heap with O(1) Push, amortised O(1) decrease key, amortised O(log n) Pop and
+// O(min(n, m)) Meld.
+type FibonacciHeap[T Node] struct {
+	// top is the root with the highest priority in the circular root list.
+	top *fibonacciNode[T]
+	m   map[string]*fibonacciNode[T]
+	mu  sync.RWMutex
+}
+
+func (h *FibonacciHeap[T]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.m)
+}
+
+func (h *FibonacciHeap[T]) Peek() Node {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.top == nil {
+		return nil
+	}
+
+	return h.top.data
+}
+
+func (h *FibonacciHeap[T]) Find(id string) (Node, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	return n.data, nil
+}
+
+func (h *FibonacciHeap[T]) Pop() Node {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.top == nil {
+		return nil
+	}
+
+	top := h.top
+	h.removeTop()
+	delete(h.m, top.data.GetUniqueID())
+
+	return top.data
+}
+
+func (h *FibonacciHeap[T]) Push(node T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := node.GetUniqueID()
+	if _, ok := h.m[id]; ok {
+		return itemAlreadyExistsError(id)
+	}
+
+	n := &fibonacciNode[T]{data: node}
+	n.left, n.right = n, n
+	h.m[id] = n
+	h.addRoot(n)
+
+	return nil
+}
+
+func (h *FibonacciHeap[T]) Update(id string, updates func(old T) T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return itemNotExistsError(id)
+	}
+
+	old := n.data
+	data := updates(old)
+	delete(h.m, id)
+	h.m[data.GetUniqueID()] = n
+
+	// decrease key cuts the node when it has a higher priority than its parent, otherwise the node is reinserted.
+	if !old.Less(data) {
+		n.data = data
+		if p := n.parent; p != nil && data.Less(p.data) {
+			h.cut(n)
+		}
+
+		if data.Less(h.top.data) {
+			h.top = n
+		}
+
+		return nil
+	}
+
+	h.detach(n)
+	n.data = data
+	h.addRoot(n)
+
+	return nil
+}
+
+func (h *FibonacciHeap[T]) Remove(id string) (Node, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	h.detach(n)
+	delete(h.m, id)
+
+	return n.data, nil
+}
+
+// Meld splices the root list of other into h in O(1) and merges their id indexes.
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) error {
+	if h == other {
+		return meldItselfError()
+	}
+
+	defer lockPair(&h.mu, &other.mu)()
+
+	m, err := meldIndex(h.m, other.m)
+	if err != nil {
+		return err
+	}
+
+	h.m = m
+	if other.top != nil {
+		h.addRoot(other.top)
+	}
+
+	other.top = nil
+	other.m = make(map[string]*fibonacciNode[T])
+
+	return nil
+}
+
+// addRoot splices the circular list starting at n into the root list.
+func (h *FibonacciHeap[T]) addRoot(n *fibonacciNode[T]) {
+	if h.top == nil {
+		h.top = n
+		return
+	}
+
+	splice(h.top, n)
+	if n.data.Less(h.top.data) {
+		h.top = n
+	}
+}
+
+// detach removes node n from the heap and resets its links.
+func (h *FibonacciHeap[T]) detach(n *fibonacciNode[T]) {
+	if n.parent != nil {
+		h.cut(n)
+	}
+
+	h.top = n
+	h.removeTop()
+	n.left, n.right = n, n
+	n.child = nil
+	n.degree = 0
+}
+
+func (h *FibonacciHeap[T]) removeTop() {
+	top := h.top
+	for c := top.child; c != nil; c = c.right {
+		c.parent = nil
+		if c.right == top.child {
+			break
+		}
+	}
+
+	if top.child != nil {
+		splice(top, top.child)
+	}
+
+	if top.right == top {
+		h.top = nil
+		return
+	}
+
+	h.top = top.right
+	unlink(top)
+	h.consolidate()
+}
+
+// consolidate links roots with the same degree until every root has a distinct degree.
+func (h *FibonacciHeap[T]) consolidate() {
+	roots := make([]*fibonacciNode[T], 0)
+	for n := h.top; ; n = n.right {
+		roots = append(roots, n)
+		if n.right == h.top {
+			break
+		}
+	}
+
+	degrees := make([]*fibonacciNode[T], 2*bits.Len(uint(len(h.m)))+2)
+	for _, n := range roots {
+		n.left, n.right = n, n
+		for degrees[n.degree] != nil {
+			other := degrees[n.degree]
+			degrees[n.degree] = nil
+			if other.data.Less(n.data) {
+				n, other = other, n
+			}
+
+			other.marked = false
+			other.parent = n
+			if n.child == nil {
+				n.child = other
+			} else {
+				splice(n.child, other)
+			}
+
+			n.degree++
+		}
+
+		degrees[n.degree] = n
+	}
+
+	h.top = nil
+	for _, n := range degrees {
+		if n != nil {
+			h.addRoot(n)
+		}
+	}
+}
+
+// cut moves node n to the root list, and cascades to the marked ancestors.
+func (h *FibonacciHeap[T]) cut(n *fibonacciNode[T]) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if p.child == n {
+			p.child = n.right
+			if n.right == n {
+				p.child = nil
+			}
+		}
+
+		unlink(n)
+		p.degree--
+		n.parent = nil
+		n.marked = false
+		h.addRoot(n)
+
+		if !p.marked {
+			p.marked = p.parent != nil
+			return
+		}
+	}
+}
+
+// splice joins the circular lists containing a and b.
+func splice[T Node](a, b *fibonacciNode[T]) {
+	aRight, bLeft := a.right, b.left
+	a.right, b.left = b, a
+	bLeft.right, aRight.left = aRight, bLeft
+}
+
+// unlink removes n from its circular list.
+func unlink[T Node](n *fibonacciNode[T]) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
+
+// NewFibonacci creates a FibonacciHeap from a copy of items, items with duplicated ids are skipped.
+func NewFibonacci[T Node](items []T) *FibonacciHeap[T] {
+	h := FibonacciHeap[T]{m: make(map[string]*fibonacciNode[T])}
+	for _, item := range items {
+		_ = h.Push(item)
+	}
+
+	return &h
+}
@@ -0,0 +1,348 @@
+package heap
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// FuncHeap is a d-ary heap ordered by less and indexed by id, so any type can be stored without implementing Node.
+type FuncHeap[T any, ID comparable] struct {
+	nodes *[]T
+	d     int
+	m     map[ID]int
+	id    func(item T) ID
+	less  func(a, b T) bool
+	mu    sync.RWMutex
+}
+
+func (h *FuncHeap[T, ID]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.len()
+}
+
+func (h *FuncHeap[T, ID]) Peek() (T, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.len() == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return (*h.nodes)[0], true
+}
+
+func (h *FuncHeap[T, ID]) Find(id ID) (T, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	idx, ok := h.m[id]
+	if !ok {
+		var empty T
+		return empty, false
+	}
+
+	return (*h.nodes)[idx], true
+}
+
+func (h *FuncHeap[T, ID]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := h.len() - 1
+	if n < 0 {
+		var empty T
+		return empty, false
+	}
+
+	h.swap(0, n)
+	h.down(0, n)
+	return h.pop(), true
+}
+
+func (h *FuncHeap[T, ID]) Push(item T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.id(item)
+	if _, ok := h.m[id]; ok {
+		return itemAlreadyExistsError(id)
+	}
+
+	*h.nodes = append(*h.nodes, item)
+	n := h.len() - 1
+	h.m[id] = n
+	h.up(n)
+
+	return nil
+}
+
+func (h *FuncHeap[T, ID]) Update(id ID, updates func(old T) T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx, ok := h.m[id]
+	if !ok {
+		return itemNotExistsError(id)
+	}
+
+	old := (*h.nodes)[idx]
+	data := updates(old)
+	delete(h.m, id)
+	(*h.nodes)[idx] = data
+	h.m[h.id(data)] = idx
+	h.fix(idx, h.len())
+
+	return nil
+}
+
+func (h *FuncHeap[T, ID]) Remove(id ID) (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx, ok := h.m[id]
+	if !ok {
+		var empty T
+		return empty, false
+	}
+
+	n := h.len() - 1
+	if n != idx {
+		h.swap(n, idx)
+		h.fix(idx, n)
+	}
+
+	return h.pop(), true
+}
+
+// PushMany adds items under a single lock. Nothing is added when any id already exists or is repeated in items.
+// The heap is rebuilt with Floyd's algorithm when that is cheaper than sifting up every item.
+func (h *FuncHeap[T, ID]) PushMany(items ...T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := h.len()
+	for i, item := range items {
+		id := h.id(item)
+		if _, ok := h.m[id]; ok {
+			for _, added := range items[:i] {
+				delete(h.m, h.id(added))
+			}
+
+			return itemAlreadyExistsError(id)
+		}
+
+		h.m[id] = n + i
+	}
+
+	*h.nodes = append(*h.nodes, items...)
+	if heapifyCheaper(n, len(items)) {
+		h.heapify()
+		return nil
+	}
+
+	for i := n; i < h.len(); i++ {
+		h.up(i)
+	}
+
+	return nil
+}
+
+// PopN removes and returns up to n items in priority order under a single lock.
+func (h *FuncHeap[T, ID]) PopN(n int) []T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n > h.len() {
+		n = h.len()
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	items := make([]T, 0, n)
+	for i := 0; i < n; i++ {
+		last := h.len() - 1
+		h.swap(0, last)
+		h.down(0, last)
+		items = append(items, h.pop())
+	}
+
+	return items
+}
+
+// RemoveWhere removes and returns all items matching pred under a single lock, the heap is rebuilt once afterwards.
+func (h *FuncHeap[T, ID]) RemoveWhere(pred func(item T) bool) []T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	removed := make([]T, 0)
+	nodes := *h.nodes
+	kept := nodes[:0]
+	for _, item := range nodes {
+		if pred(item) {
+			removed = append(removed, item)
+			delete(h.m, h.id(item))
+			continue
+		}
+
+		kept = append(kept, item)
+	}
+
+	if len(removed) == 0 {
+		return removed
+	}
+
+	var empty T
+	for i := len(kept); i < len(nodes); i++ {
+		nodes[i] = empty
+	}
+
+	*h.nodes = kept
+	h.init()
+
+	return removed
+}
+
+// Drain removes all items and returns them in priority order. The items are sorted in place, so the returned
+// slice reuses the heap's backing array.
+func (h *FuncHeap[T, ID]) Drain() []T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	nodes := *h.nodes
+	h.sort(nodes)
+	*h.nodes = nil
+	h.m = make(map[ID]int)
+
+	return nodes
+}
+
+// ToSortedSlice returns a copy of all items in priority order, the heap is not changed.
+func (h *FuncHeap[T, ID]) ToSortedSlice() []T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	nodes := make([]T, h.len())
+	copy(nodes, *h.nodes)
+	h.sort(nodes)
+
+	return nodes
+}
+
+func (h *FuncHeap[T, ID]) init() {
+	n := h.len()
+	for i := 0; i < n; i++ {
+		h.m[h.id((*h.nodes)[i])] = i
+	}
+
+	h.heapify()
+}
+
+func (h *FuncHeap[T, ID]) heapify() {
+	n := h.len()
+	for i := (n - 1) / h.d; i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+func (h *FuncHeap[T, ID]) len() int {
+	return len(*h.nodes)
+}
+
+func (h *FuncHeap[T, ID]) swap(i, j int) {
+	nodes := *h.nodes
+	nodes[i], nodes[j] = nodes[j], nodes[i]
+	h.m[h.id(nodes[i])] = i
+	h.m[h.id(nodes[j])] = j
+}
+
+func (h *FuncHeap[T, ID]) pop() T {
+	n := h.len() - 1
+	nodes := *h.nodes
+	last := nodes[n]
+
+	delete(h.m, h.id(last))
+	*h.nodes = nodes[:n]
+
+	return last
+}
+
+func (h *FuncHeap[T, ID]) fix(idx, n int) {
+	if !h.down(idx, n) {
+		h.up(idx)
+	}
+}
+
+func (h *FuncHeap[T, ID]) up(idx int) {
+	nodes := *h.nodes
+	for {
+		parent := (idx - 1) / h.d
+		if parent == idx || !h.less(nodes[idx], nodes[parent]) {
+			break
+		}
+
+		h.swap(idx, parent)
+		idx = parent
+	}
+}
+
+func (h *FuncHeap[T, ID]) down(idx, n int) bool {
+	nodes := *h.nodes
+	return SiftDown(h.d, idx, n, func(i, j int) bool {
+		return h.less(nodes[i], nodes[j])
+	}, h.swap) > idx
+}
+
+// sort sorts nodes, which are in heap order, into priority order without touching the id index.
+func (h *FuncHeap[T, ID]) sort(nodes []T) {
+	less := func(i, j int) bool {
+		return h.less(nodes[i], nodes[j])
+	}
+
+	swap := func(i, j int) {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+
+	for end := len(nodes) - 1; end > 0; end-- {
+		swap(0, end)
+		SiftDown(h.d, 0, end, less, swap)
+	}
+
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
+
+// NewFunc creates a FuncHeap from a copy of items, id returns the unique id of an item and less reports whether
+// a should be popped before b.
+func NewFunc[T any, ID comparable](d int, items []T, id func(item T) ID, less func(a, b T) bool) *FuncHeap[T, ID] {
+	nodes := make([]T, len(items))
+	copy(nodes, items)
+
+	return newFuncHeap(d, &nodes, id, less)
+}
+
+func newFuncHeap[T any, ID comparable](d int, items *[]T, id func(item T) ID, less func(a, b T) bool) *FuncHeap[T, ID] {
+	if d < 2 {
+		panic(invalidArityError(d))
+	}
+
+	h := FuncHeap[T, ID]{d: d, nodes: items, m: make(map[ID]int), id: id, less: less}
+	h.init()
+
+	return &h
+}
+
+// heapifyCheaper reports whether rebuilding a heap of n+k items, O(n+k), beats sifting up k items, O(k log(n+k)).
+func heapifyCheaper(n, k int) bool {
+	return k*bits.Len(uint(n+k)) > n+k
+}
+
+func invalidArityError(d int) error {
+	return fmt.Errorf(`d must be at least 2, got %v`, d)
+}
@@ -0,0 +1,168 @@
+package heap
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFuncHeap_Int(t *testing.T) {
+	cases := map[string]struct {
+		items    []int
+		push     []int
+		remove   []int
+		expected []int
+		err      error
+	}{
+		"pop in ascending order": {
+			items:    []int{5, 1, 4, 2, 3},
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		"push and remove items": {
+			items:    []int{5, 1, 4},
+			push:     []int{0, 6},
+			remove:   []int{1, 6},
+			expected: []int{0, 4, 5},
+		},
+		"push exists item": {
+			items:    []int{2, 1},
+			push:     []int{1},
+			expected: []int{1, 2},
+			err:      errors.New("id 1 already exists"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewFunc(2, tc.items, func(i int) int {
+				return i
+			}, func(a, b int) bool {
+				return a < b
+			})
+
+			for _, i := range tc.push {
+				a.Equal(tc.err, h.Push(i))
+			}
+
+			for _, i := range tc.remove {
+				item, ok := h.Remove(i)
+				a.Equal(i, item)
+				a.True(ok)
+			}
+
+			items := make([]int, 0)
+			for i, ok := h.Pop(); ok; i, ok = h.Pop() {
+				items = append(items, i)
+			}
+
+			a.Equal(tc.expected, items)
+		})
+	}
+}
+
+func TestFuncHeap_Struct(t *testing.T) {
+	type job struct {
+		id       int
+		priority int
+	}
+
+	cases := map[string]struct {
+		updateID int
+		priority int
+		expected []job
+		err      error
+	}{
+		"update priority": {
+			updateID: 3,
+			priority: 10,
+			expected: []job{
+				{id: 3, priority: 10},
+				{id: 2, priority: 5},
+				{id: 1, priority: 1},
+			},
+		},
+		"update item not found": {
+			updateID: 4,
+			expected: []job{
+				{id: 2, priority: 5},
+				{id: 3, priority: 3},
+				{id: 1, priority: 1},
+			},
+			err: errors.New("id 4 not found"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			items := []job{{id: 1, priority: 1}, {id: 2, priority: 5}, {id: 3, priority: 3}}
+			h := NewFunc(4, items, func(j job) int {
+				return j.id
+			}, func(a, b job) bool {
+				return a.priority > b.priority
+			})
+
+			err := h.Update(tc.updateID, func(old job) job {
+				return job{id: old.id, priority: tc.priority}
+			})
+			a.Equal(tc.err, err)
+
+			top, ok := h.Peek()
+			a.True(ok)
+			a.Equal(tc.expected[0], top)
+
+			found, ok := h.Find(2)
+			a.True(ok)
+			a.Equal(job{id: 2, priority: 5}, found)
+
+			popped := make([]job, 0)
+			for j, ok := h.Pop(); ok; j, ok = h.Pop() {
+				popped = append(popped, j)
+			}
+
+			a.Equal(tc.expected, popped)
+			a.Equal(items[0], job{id: 1, priority: 1})
+		})
+	}
+}
+
+func TestFuncHeap_Allocations(t *testing.T) {
+	cases := map[string]struct {
+		size int
+	}{
+		"update without allocation": {
+			size: 1000,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			items := make([]int, tc.size)
+			priorities := make(map[int]int)
+			for i := range items {
+				items[i] = i
+				priorities[i] = i
+			}
+
+			h := NewFunc(2, items, func(i int) int {
+				return i
+			}, func(a, b int) bool {
+				return priorities[a] < priorities[b]
+			})
+
+			priority := tc.size
+			allocs := testing.AllocsPerRun(100, func() {
+				top, _ := h.Peek()
+				priority++
+				priorities[top] = priority
+				_ = h.Update(top, func(old int) int {
+					return old
+				})
+			})
+
+			a.Equal(float64(0), allocs)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package heap
 import (
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -253,6 +255,458 @@ func TestDHeap_Remote(t *testing.T) {
 	}
 }
 
+func TestDHeap_PeekItem(t *testing.T) {
+	cases := map[string]struct {
+		dh       *DHeap[testItem]
+		expected testItem
+		ok       bool
+	}{
+		"peek top item": {
+			dh:       setupTestData(),
+			expected: testItem{Priority: 5, Value: "B"},
+			ok:       true,
+		},
+		"peek empty heap": {
+			dh: New(3, &[]testItem{}),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			length := tc.dh.Len()
+			item, ok := tc.dh.PeekItem()
+
+			a.Equal(tc.expected, item)
+			a.Equal(tc.ok, ok)
+			a.Equal(length, tc.dh.Len())
+
+			if !tc.ok {
+				a.Nil(tc.dh.Peek())
+			}
+		})
+	}
+}
+
+func TestDHeap_PopItem(t *testing.T) {
+	cases := map[string]struct {
+		dh       *DHeap[testItem]
+		expected testItem
+		ok       bool
+	}{
+		"pop top item": {
+			dh:       setupTestData(),
+			expected: testItem{Priority: 5, Value: "B"},
+			ok:       true,
+		},
+		"pop from empty heap": {
+			dh: New(3, &[]testItem{}),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			length := tc.dh.Len()
+			item, ok := tc.dh.PopItem()
+
+			a.Equal(tc.expected, item)
+			a.Equal(tc.ok, ok)
+			if ok {
+				a.Equal(length-1, tc.dh.Len())
+				return
+			}
+
+			a.Equal(0, tc.dh.Len())
+		})
+	}
+}
+
+func TestDHeap_FindItem(t *testing.T) {
+	cases := map[string]struct {
+		id       string
+		expected testItem
+		ok       bool
+	}{
+		"find exists item": {
+			id:       "C",
+			expected: testItem{Priority: 3, Value: "C"},
+			ok:       true,
+		},
+		"find not exists item": {
+			id: "F",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			item, ok := setupTestData().FindItem(tc.id)
+
+			a.Equal(tc.expected, item)
+			a.Equal(tc.ok, ok)
+		})
+	}
+}
+
+func TestDHeap_RemoveItem(t *testing.T) {
+	cases := map[string]struct {
+		removeID    string
+		removedItem testItem
+		ok          bool
+		expected    []testItem
+	}{
+		"remove item by id": {
+			removeID:    "C",
+			removedItem: testItem{Priority: 3, Value: "C"},
+			ok:          true,
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+		"remove item not found": {
+			removeID: "F",
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dh := setupTestData()
+			item, ok := dh.RemoveItem(tc.removeID)
+
+			a.Equal(tc.removedItem, item)
+			a.Equal(tc.ok, ok)
+			a.Equal(tc.expected, getItems(dh))
+		})
+	}
+}
+
+func TestDHeap_Drain(t *testing.T) {
+	cases := map[string]struct {
+		items    []testItem
+		expected []testItem
+	}{
+		"drain items in priority order": {
+			items: []testItem{
+				{Priority: 2, Value: "A"},
+				{Priority: 5, Value: "B"},
+				{Priority: 3, Value: "C"},
+				{Priority: 1, Value: "D"},
+				{Priority: 4, Value: "E"},
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+		"drain empty heap": {
+			items:    []testItem{},
+			expected: []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			items := tc.items
+			dh := New(3, &items)
+
+			a.Equal(tc.expected, dh.Drain())
+			a.Equal(0, dh.Len())
+			a.Equal(tc.expected, tc.items)
+
+			a.Nil(dh.Push(testItem{Priority: 1, Value: "F"}))
+			a.Equal([]testItem{{Priority: 1, Value: "F"}}, getItems(dh))
+		})
+	}
+}
+
+func TestDHeap_ToSortedSlice(t *testing.T) {
+	cases := map[string]struct {
+		expected []testItem
+	}{
+		"sorted copy of items": {
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dh := setupTestData()
+
+			a.Equal(tc.expected, dh.ToSortedSlice())
+			a.Equal(len(tc.expected), dh.Len())
+			a.Equal(tc.expected, getItems(dh))
+		})
+	}
+}
+
+func TestFrom(t *testing.T) {
+	cases := map[string]struct {
+		items    []testItem
+		expected []testItem
+	}{
+		"caller slice is not changed": {
+			items: []testItem{
+				{Priority: 2, Value: "A"},
+				{Priority: 5, Value: "B"},
+				{Priority: 3, Value: "C"},
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			original := make([]testItem, len(tc.items))
+			copy(original, tc.items)
+
+			dh := From(2, tc.items)
+			a.Equal(tc.expected, getItems(dh))
+			a.Equal(original, tc.items)
+		})
+	}
+}
+
+func TestNew_InvalidArity(t *testing.T) {
+	cases := map[string]struct {
+		d   int
+		err string
+	}{
+		"zero arity": {
+			d:   0,
+			err: "d must be at least 2, got 0",
+		},
+		"negative arity": {
+			d:   -1,
+			err: "d must be at least 2, got -1",
+		},
+		"unary heap": {
+			d:   1,
+			err: "d must be at least 2, got 1",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			a.PanicsWithError(tc.err, func() {
+				New(tc.d, &[]testItem{})
+			})
+
+			a.PanicsWithError(tc.err, func() {
+				From(tc.d, []testItem{})
+			})
+		})
+	}
+}
+
+func TestDHeap_PushMany(t *testing.T) {
+	cases := map[string]struct {
+		items    []testItem
+		expected []testItem
+		err      error
+	}{
+		"push few items": {
+			items: []testItem{
+				{Priority: 6, Value: "F"},
+			},
+			expected: []testItem{
+				{Priority: 6, Value: "F"},
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+		"push many items": {
+			items: []testItem{
+				{Priority: 0, Value: "F"},
+				{Priority: 7, Value: "G"},
+				{Priority: 10, Value: "H"},
+				{Priority: 9, Value: "I"},
+				{Priority: 6, Value: "J"},
+				{Priority: 8, Value: "K"},
+			},
+			expected: []testItem{
+				{Priority: 10, Value: "H"},
+				{Priority: 9, Value: "I"},
+				{Priority: 8, Value: "K"},
+				{Priority: 7, Value: "G"},
+				{Priority: 6, Value: "J"},
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+				{Priority: 0, Value: "F"},
+			},
+		},
+		"push exists item": {
+			items: []testItem{
+				{Priority: 6, Value: "F"},
+				{Priority: 6, Value: "A"},
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+			err: errors.New("id A already exists"),
+		},
+		"push repeated item": {
+			items: []testItem{
+				{Priority: 6, Value: "F"},
+				{Priority: 7, Value: "F"},
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+			err: errors.New("id F already exists"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dh := setupTestData()
+
+			a.Equal(tc.err, dh.PushMany(tc.items...))
+			a.Equal(len(tc.expected), dh.Len())
+			a.Equal(tc.expected, getItems(dh))
+		})
+	}
+}
+
+func TestDHeap_PopN(t *testing.T) {
+	cases := map[string]struct {
+		n        int
+		expected []testItem
+		left     int
+	}{
+		"pop some items": {
+			n: 2,
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+			},
+			left: 3,
+		},
+		"pop more items than the heap has": {
+			n: 10,
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+			left: 0,
+		},
+		"pop no item": {
+			n:        -1,
+			expected: []testItem{},
+			left:     5,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dh := setupTestData()
+
+			a.Equal(tc.expected, dh.PopN(tc.n))
+			a.Equal(tc.left, dh.Len())
+		})
+	}
+}
+
+func TestDHeap_RemoveWhere(t *testing.T) {
+	cases := map[string]struct {
+		pred     func(item testItem) bool
+		removed  []string
+		expected []testItem
+	}{
+		"remove matched items": {
+			pred: func(item testItem) bool {
+				return item.Priority%2 == 1
+			},
+			removed: []string{"B", "C", "D"},
+			expected: []testItem{
+				{Priority: 4, Value: "E"},
+				{Priority: 2, Value: "A"},
+			},
+		},
+		"remove no item": {
+			pred: func(item testItem) bool {
+				return false
+			},
+			removed: []string{},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dh := setupTestData()
+
+			removed := make([]string, 0)
+			for _, item := range dh.RemoveWhere(tc.pred) {
+				removed = append(removed, item.Value)
+			}
+
+			a.ElementsMatch(tc.removed, removed)
+			for _, id := range tc.removed {
+				_, ok := dh.FindItem(id)
+				a.False(ok)
+			}
+
+			a.Equal(tc.expected, getItems(dh))
+		})
+	}
+}
+
 func TestDHeap_ConcurrentWrite(t *testing.T) {
 	cases := map[string]struct {
 		updates  map[string]testItem
@@ -383,3 +837,26 @@ func (i testItem) GetUniqueID() string {
 func (i testItem) Less(data Node) bool {
 	return i.Priority > data.(testItem).Priority
 }
+
+func BenchmarkDHeap_PushMany(b *testing.B) {
+	items := make([]testItem, 10000)
+	for i := range items {
+		items[i] = testItem{Priority: rand.Intn(len(items)), Value: strconv.Itoa(i)}
+	}
+
+	b.Run("Push", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dh := New(4, &[]testItem{})
+			for _, item := range items {
+				_ = dh.Push(item)
+			}
+		}
+	})
+
+	b.Run("PushMany", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dh := New(4, &[]testItem{})
+			_ = dh.PushMany(items...)
+		}
+	})
+}
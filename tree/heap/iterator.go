@@ -0,0 +1,52 @@
+package heap
+
+// Iterator yields the items of a heap snapshot in priority order without changing the heap. It keeps a frontier heap
+// of snapshot indices, starting from the root, and pushes the children of every index it yields, so the first k
+// items take O(k log k) after the snapshot is copied.
+type Iterator[T any] struct {
+	nodes    []T
+	d        int
+	frontier *FuncHeap[int, int]
+}
+
+// Next returns the next item in priority order, it returns false when all items have been returned.
+func (it *Iterator[T]) Next() (T, bool) {
+	idx, ok := it.frontier.Pop()
+	if !ok {
+		var empty T
+		return empty, false
+	}
+
+	for i := idx*it.d + 1; i <= idx*it.d+it.d && i < len(it.nodes); i++ {
+		_ = it.frontier.Push(i)
+	}
+
+	return it.nodes[idx], true
+}
+
+// Iterator returns an Iterator over a snapshot of the heap, later changes to the heap are not seen.
+func (h *FuncHeap[T, ID]) Iterator() *Iterator[T] {
+	it := &Iterator[T]{nodes: h.Items(), d: h.d}
+	it.frontier = newFuncHeap(h.d, &[]int{}, func(idx int) int {
+		return idx
+	}, func(a, b int) bool {
+		return h.less(it.nodes[a], it.nodes[b])
+	})
+
+	if len(it.nodes) > 0 {
+		_ = it.frontier.Push(0)
+	}
+
+	return it
+}
+
+// Items returns a copy of all items in no particular order.
+func (h *FuncHeap[T, ID]) Items() []T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	nodes := make([]T, h.len())
+	copy(nodes, *h.nodes)
+
+	return nodes
+}
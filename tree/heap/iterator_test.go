@@ -0,0 +1,102 @@
+package heap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIterator_Next(t *testing.T) {
+	cases := map[string]struct {
+		limit    int
+		expected []testItem
+	}{
+		"iterate all items": {
+			limit: 10,
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+		"iterate first items": {
+			limit: 2,
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dh := setupTestData()
+			it := dh.Iterator()
+
+			a.Nil(dh.Push(testItem{Priority: 9, Value: "F"}))
+
+			items := make([]testItem, 0)
+			for item, ok := it.Next(); ok && len(items) < tc.limit; item, ok = it.Next() {
+				items = append(items, item)
+			}
+
+			a.Equal(tc.expected, items)
+			a.Equal(6, dh.Len())
+		})
+	}
+}
+
+func TestIterator_Empty(t *testing.T) {
+	cases := map[string]struct {
+		items []int
+	}{
+		"iterate empty heap": {
+			items: []int{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewFunc(2, tc.items, func(i int) int {
+				return i
+			}, func(a, b int) bool {
+				return a < b
+			})
+
+			_, ok := h.Iterator().Next()
+			a.False(ok)
+		})
+	}
+}
+
+func TestFuncHeap_Items(t *testing.T) {
+	cases := map[string]struct {
+		items []int
+	}{
+		"copy items": {
+			items: []int{5, 1, 4, 2, 3},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewFunc(3, tc.items, func(i int) int {
+				return i
+			}, func(a, b int) bool {
+				return a < b
+			})
+
+			items := h.Items()
+			a.ElementsMatch(tc.items, items)
+
+			items[0] = 100
+			top, _ := h.Peek()
+			a.Equal(1, top)
+			a.Equal(len(tc.items), h.Len())
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package heap
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// MinMaxHeap is a double-ended priority queue. Nodes are ordered by Node.Less, Min is the
+// node DHeap would pop first and Max is the node DHeap would pop last.
+type MinMaxHeap[T Node] struct {
+	nodes []T
+	m     map[string]int
+	mu    sync.RWMutex
+}
+
+func (h *MinMaxHeap[T]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.nodes)
+}
+
+func (h *MinMaxHeap[T]) PeekMin() Node {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.nodes) == 0 {
+		return nil
+	}
+
+	return h.nodes[0]
+}
+
+func (h *MinMaxHeap[T]) PeekMax() Node {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.nodes) == 0 {
+		return nil
+	}
+
+	return h.nodes[h.maxIndex()]
+}
+
+func (h *MinMaxHeap[T]) Find(id string) (Node, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	idx, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	return h.nodes[idx], nil
+}
+
+func (h *MinMaxHeap[T]) PopMin() Node {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.nodes) == 0 {
+		return nil
+	}
+
+	return h.remove(0)
+}
+
+func (h *MinMaxHeap[T]) PopMax() Node {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.nodes) == 0 {
+		return nil
+	}
+
+	return h.remove(h.maxIndex())
+}
+
+func (h *MinMaxHeap[T]) Push(node T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := node.GetUniqueID()
+	if _, ok := h.m[id]; ok {
+		return itemAlreadyExistsError(id)
+	}
+
+	h.nodes = append(h.nodes, node)
+	n := len(h.nodes) - 1
+	h.m[id] = n
+	h.fix(n)
+
+	return nil
+}
+
+func (h *MinMaxHeap[T]) Update(id string, updates func(old T) T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx, ok := h.m[id]
+	if !ok {
+		return itemNotExistsError(id)
+	}
+
+	data := updates(h.nodes[idx])
+	delete(h.m, id)
+	h.nodes[idx] = data
+	h.m[data.GetUniqueID()] = idx
+	h.fix(idx)
+
+	return nil
+}
+
+func (h *MinMaxHeap[T]) Remove(id string) (Node, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	return h.remove(idx), nil
+}
+
+func (h *MinMaxHeap[T]) init() {
+	n := len(h.nodes)
+	for i := 0; i < n; i++ {
+		h.m[h.nodes[i].GetUniqueID()] = i
+	}
+
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.nodes) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+
+	if h.less(1, 2) {
+		return 2
+	}
+
+	return 1
+}
+
+func (h *MinMaxHeap[T]) remove(idx int) Node {
+	n := len(h.nodes) - 1
+	if idx != n {
+		h.swap(idx, n)
+	}
+
+	last := h.nodes[n]
+	delete(h.m, last.GetUniqueID())
+	h.nodes = h.nodes[:n]
+
+	if idx < n {
+		h.fix(idx)
+	}
+
+	return last
+}
+
+func (h *MinMaxHeap[T]) less(i, j int) bool {
+	return h.nodes[i].Less(h.nodes[j])
+}
+
+// before reports whether node i should sit above node j on a min level (isMin) or a max level.
+func (h *MinMaxHeap[T]) before(i, j int, isMin bool) bool {
+	if isMin {
+		return h.less(i, j)
+	}
+
+	return h.less(j, i)
+}
+
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+	h.m[h.nodes[i].GetUniqueID()] = i
+	h.m[h.nodes[j].GetUniqueID()] = j
+}
+
+func (h *MinMaxHeap[T]) fix(idx int) {
+	if idx > 0 {
+		isMin := isMinLevel(idx)
+		if parent := (idx - 1) / 2; h.before(idx, parent, !isMin) {
+			h.swap(idx, parent)
+			h.upLevel(parent, !isMin)
+			h.down(idx)
+			return
+		}
+
+		h.upLevel(idx, isMin)
+	}
+
+	h.down(idx)
+}
+
+func (h *MinMaxHeap[T]) upLevel(idx int, isMin bool) {
+	for idx > 2 {
+		grandparent := ((idx-1)/2 - 1) / 2
+		if !h.before(idx, grandparent, isMin) {
+			break
+		}
+
+		h.swap(idx, grandparent)
+		idx = grandparent
+	}
+}
+
+func (h *MinMaxHeap[T]) down(idx int) {
+	isMin := isMinLevel(idx)
+	n := len(h.nodes)
+	for {
+		firstChild := 2*idx + 1
+		if firstChild >= n {
+			return
+		}
+
+		// the extreme node is one of the children or grandchildren.
+		extreme := firstChild
+		for _, i := range []int{firstChild + 1, 2*firstChild + 1, 2*firstChild + 2, 2*firstChild + 3, 2*firstChild + 4} {
+			if i < n && h.before(i, extreme, isMin) {
+				extreme = i
+			}
+		}
+
+		if !h.before(extreme, idx, isMin) {
+			return
+		}
+
+		h.swap(extreme, idx)
+		if extreme <= firstChild+1 {
+			return
+		}
+
+		if parent := (extreme - 1) / 2; h.before(parent, extreme, isMin) {
+			h.swap(extreme, parent)
+		}
+
+		idx = extreme
+	}
+}
+
+func isMinLevel(idx int) bool {
+	return bits.Len(uint(idx+1))%2 == 1
+}
+
+func NewMinMax[T Node](items []T) *MinMaxHeap[T] {
+	nodes := make([]T, len(items))
+	copy(nodes, items)
+
+	h := MinMaxHeap[T]{nodes: nodes, m: make(map[string]int)}
+	h.init()
+
+	return &h
+}
@@ -0,0 +1,251 @@
+package heap
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMinMaxHeap_Peek(t *testing.T) {
+	cases := map[string]struct {
+		items       []testItem
+		expectedMin Node
+		expectedMax Node
+	}{
+		"peek both ends": {
+			items:       getTestItems(),
+			expectedMin: testItem{Priority: 5, Value: "B"},
+			expectedMax: testItem{Priority: 1, Value: "D"},
+		},
+		"peek single item": {
+			items:       []testItem{{Priority: 1, Value: "A"}},
+			expectedMin: testItem{Priority: 1, Value: "A"},
+			expectedMax: testItem{Priority: 1, Value: "A"},
+		},
+		"peek empty heap": {
+			items: []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewMinMax(tc.items)
+
+			a.Equal(tc.expectedMin, h.PeekMin())
+			a.Equal(tc.expectedMax, h.PeekMax())
+			a.Equal(len(tc.items), h.Len())
+		})
+	}
+}
+
+func TestMinMaxHeap_Pop(t *testing.T) {
+	cases := map[string]struct {
+		popMax   []bool
+		expected []Node
+	}{
+		"pop min items": {
+			popMax: []bool{false, false, false},
+			expected: []Node{
+				testItem{Priority: 5, Value: "B"},
+				testItem{Priority: 4, Value: "E"},
+				testItem{Priority: 3, Value: "C"},
+			},
+		},
+		"pop max items": {
+			popMax: []bool{true, true, true},
+			expected: []Node{
+				testItem{Priority: 1, Value: "D"},
+				testItem{Priority: 2, Value: "A"},
+				testItem{Priority: 3, Value: "C"},
+			},
+		},
+		"pop from both ends until empty": {
+			popMax: []bool{true, false, true, false, true, true},
+			expected: []Node{
+				testItem{Priority: 1, Value: "D"},
+				testItem{Priority: 5, Value: "B"},
+				testItem{Priority: 2, Value: "A"},
+				testItem{Priority: 4, Value: "E"},
+				testItem{Priority: 3, Value: "C"},
+				nil,
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewMinMax(getTestItems())
+
+			items := make([]Node, 0)
+			for _, popMax := range tc.popMax {
+				if popMax {
+					items = append(items, h.PopMax())
+					continue
+				}
+
+				items = append(items, h.PopMin())
+			}
+
+			a.Equal(tc.expected, items)
+		})
+	}
+}
+
+func TestMinMaxHeap_Push(t *testing.T) {
+	cases := map[string]struct {
+		item        testItem
+		expectedMin Node
+		expectedMax Node
+		err         error
+	}{
+		"push new min item": {
+			item:        testItem{Priority: 6, Value: "F"},
+			expectedMin: testItem{Priority: 6, Value: "F"},
+			expectedMax: testItem{Priority: 1, Value: "D"},
+		},
+		"push new max item": {
+			item:        testItem{Priority: 0, Value: "F"},
+			expectedMin: testItem{Priority: 5, Value: "B"},
+			expectedMax: testItem{Priority: 0, Value: "F"},
+		},
+		"push exists item": {
+			item:        testItem{Priority: 6, Value: "A"},
+			expectedMin: testItem{Priority: 5, Value: "B"},
+			expectedMax: testItem{Priority: 1, Value: "D"},
+			err:         errors.New("id A already exists"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewMinMax(getTestItems())
+
+			a.Equal(tc.err, h.Push(tc.item))
+			a.Equal(tc.expectedMin, h.PeekMin())
+			a.Equal(tc.expectedMax, h.PeekMax())
+		})
+	}
+}
+
+func TestMinMaxHeap_Update(t *testing.T) {
+	cases := map[string]struct {
+		updatedID   string
+		updatedItem testItem
+		expected    []Node
+		err         error
+	}{
+		"update item to min": {
+			updatedID:   "D",
+			updatedItem: testItem{Priority: 9, Value: "D_Updated"},
+			expected: []Node{
+				testItem{Priority: 9, Value: "D_Updated"},
+				testItem{Priority: 5, Value: "B"},
+				testItem{Priority: 4, Value: "E"},
+				testItem{Priority: 3, Value: "C"},
+				testItem{Priority: 2, Value: "A"},
+			},
+		},
+		"update item to max": {
+			updatedID:   "B",
+			updatedItem: testItem{Priority: 0, Value: "B_Updated"},
+			expected: []Node{
+				testItem{Priority: 4, Value: "E"},
+				testItem{Priority: 3, Value: "C"},
+				testItem{Priority: 2, Value: "A"},
+				testItem{Priority: 1, Value: "D"},
+				testItem{Priority: 0, Value: "B_Updated"},
+			},
+		},
+		"update item not found": {
+			updatedID: "F",
+			expected: []Node{
+				testItem{Priority: 5, Value: "B"},
+				testItem{Priority: 4, Value: "E"},
+				testItem{Priority: 3, Value: "C"},
+				testItem{Priority: 2, Value: "A"},
+				testItem{Priority: 1, Value: "D"},
+			},
+			err: errors.New("id F not found"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewMinMax(getTestItems())
+			err := h.Update(tc.updatedID, func(_ testItem) testItem {
+				return tc.updatedItem
+			})
+
+			a.Equal(tc.err, err)
+
+			items := make([]Node, 0)
+			for h.Len() > 0 {
+				items = append(items, h.PopMin())
+			}
+
+			a.Equal(tc.expected, items)
+		})
+	}
+}
+
+func TestMinMaxHeap_Remove(t *testing.T) {
+	cases := map[string]struct {
+		removeID    string
+		removedItem Node
+		expected    []Node
+		err         error
+	}{
+		"remove item by id": {
+			removeID:    "C",
+			removedItem: testItem{Priority: 3, Value: "C"},
+			expected: []Node{
+				testItem{Priority: 1, Value: "D"},
+				testItem{Priority: 2, Value: "A"},
+				testItem{Priority: 4, Value: "E"},
+				testItem{Priority: 5, Value: "B"},
+			},
+		},
+		"remove item not found": {
+			removeID: "F",
+			err:      errors.New("id F not found"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewMinMax(getTestItems())
+			node, err := h.Remove(tc.removeID)
+
+			a.Equal(tc.err, err)
+			a.Equal(tc.removedItem, node)
+			if tc.err != nil {
+				return
+			}
+
+			_, findErr := h.Find(tc.removeID)
+			a.NotNil(findErr)
+
+			items := make([]Node, 0)
+			for h.Len() > 0 {
+				items = append(items, h.PopMax())
+			}
+
+			a.Equal(tc.expected, items)
+		})
+	}
+}
+
+func getTestItems() []testItem {
+	return []testItem{
+		{Priority: 2, Value: "A"},
+		{Priority: 5, Value: "B"},
+		{Priority: 3, Value: "C"},
+		{Priority: 1, Value: "D"},
+		{Priority: 4, Value: "E"},
+	}
+}
@@ -0,0 +1,234 @@
+package heap
+
+import "sync"
+
+type pairingNode[T Node] struct {
+	data T
+
+	// prev is the parent for the leftmost child, otherwise the left sibling.
+	prev    *pairingNode[T]
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+}
+
+// PairingHeap is a mergeable heap with O(1) Push, amortised O(log n) Pop and O(min(n, m)) Meld.
+type PairingHeap[T Node] struct {
+	root *pairingNode[T]
+	m    map[string]*pairingNode[T]
+	mu   sync.RWMutex
+}
+
+func (h *PairingHeap[T]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.m)
+}
+
+func (h *PairingHeap[T]) Peek() Node {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.root == nil {
+		return nil
+	}
+
+	return h.root.data
+}
+
+func (h *PairingHeap[T]) Find(id string) (Node, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	return n.data, nil
+}
+
+func (h *PairingHeap[T]) Pop() Node {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.root == nil {
+		return nil
+	}
+
+	root := h.root
+	h.detach(root)
+	delete(h.m, root.data.GetUniqueID())
+
+	return root.data
+}
+
+func (h *PairingHeap[T]) Push(node T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := node.GetUniqueID()
+	if _, ok := h.m[id]; ok {
+		return itemAlreadyExistsError(id)
+	}
+
+	n := &pairingNode[T]{data: node}
+	h.m[id] = n
+	h.root = linkPairing(h.root, n)
+
+	return nil
+}
+
+func (h *PairingHeap[T]) Update(id string, updates func(old T) T) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return itemNotExistsError(id)
+	}
+
+	old := n.data
+	data := updates(old)
+	delete(h.m, id)
+	h.m[data.GetUniqueID()] = n
+
+	// decrease key only needs to cut the subtree, otherwise the node is reinserted.
+	if !old.Less(data) {
+		n.data = data
+		if n != h.root {
+			cutPairing(n)
+			h.root = linkPairing(h.root, n)
+		}
+
+		return nil
+	}
+
+	h.detach(n)
+	n.data = data
+	h.root = linkPairing(h.root, n)
+
+	return nil
+}
+
+func (h *PairingHeap[T]) Remove(id string) (Node, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, ok := h.m[id]
+	if !ok {
+		return nil, itemNotExistsError(id)
+	}
+
+	h.detach(n)
+	delete(h.m, id)
+
+	return n.data, nil
+}
+
+// Meld links the root of other under h in O(1) and merges their id indexes.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) error {
+	if h == other {
+		return meldItselfError()
+	}
+
+	defer lockPair(&h.mu, &other.mu)()
+
+	m, err := meldIndex(h.m, other.m)
+	if err != nil {
+		return err
+	}
+
+	h.m = m
+	h.root = linkPairing(h.root, other.root)
+	other.root = nil
+	other.m = make(map[string]*pairingNode[T])
+
+	return nil
+}
+
+// detach removes node n from the heap and resets its links.
+func (h *PairingHeap[T]) detach(n *pairingNode[T]) {
+	children := mergePairs(n.child)
+	if n == h.root {
+		h.root = children
+	} else {
+		cutPairing(n)
+		h.root = linkPairing(h.root, children)
+	}
+
+	n.child = nil
+}
+
+func linkPairing[T Node](a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if b.data.Less(a.data) {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+
+	a.child = b
+	return a
+}
+
+func cutPairing[T Node](n *pairingNode[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+
+	n.prev = nil
+	n.sibling = nil
+}
+
+// mergePairs links siblings in pairs from left to right, then links the pairs from right to left.
+func mergePairs[T Node](first *pairingNode[T]) *pairingNode[T] {
+	pairs := make([]*pairingNode[T], 0)
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			a.prev, a.sibling = nil, nil
+			pairs = append(pairs, a)
+			break
+		}
+
+		first = b.sibling
+		a.prev, a.sibling = nil, nil
+		b.prev, b.sibling = nil, nil
+		pairs = append(pairs, linkPairing(a, b))
+	}
+
+	var root *pairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = linkPairing(pairs[i], root)
+	}
+
+	return root
+}
+
+// NewPairing creates a PairingHeap from a copy of items, items with duplicated ids are skipped.
+func NewPairing[T Node](items []T) *PairingHeap[T] {
+	h := PairingHeap[T]{m: make(map[string]*pairingNode[T])}
+	for _, item := range items {
+		_ = h.Push(item)
+	}
+
+	return &h
+}
@@ -0,0 +1,39 @@
+package heap
+
+import (
+	"fmt"
+	"github.com/CameronXie/algorithms-go/tree"
+	"io"
+)
+
+type printNode[T any] struct {
+	nodes []T
+	d     int
+	idx   int
+}
+
+func (n *printNode[T]) String() string {
+	return fmt.Sprint(n.nodes[n.idx])
+}
+
+func (n *printNode[T]) Children() []tree.NaryNode {
+	children := make([]tree.NaryNode, 0, n.d)
+	for i := n.idx*n.d + 1; i <= n.idx*n.d+n.d && i < len(n.nodes); i++ {
+		children = append(children, &printNode[T]{nodes: n.nodes, d: n.d, idx: i})
+	}
+
+	return children
+}
+
+// Print writes the heap as a d-ary tree, the children of a node are labelled from 0 to d-1.
+func (h *FuncHeap[T, ID]) Print(w io.StringWriter) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.len() == 0 {
+		_, err := w.WriteString("empty\n")
+		return err
+	}
+
+	return tree.PrintNary(&printNode[T]{nodes: *h.nodes, d: h.d}, w)
+}
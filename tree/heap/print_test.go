@@ -0,0 +1,67 @@
+package heap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDHeap_Print(t *testing.T) {
+	cases := map[string]struct {
+		items    []testItem
+		expected string
+	}{
+		"print an empty heap": {
+			items:    []testItem{},
+			expected: "empty\n",
+		},
+		"print the heap": {
+			items: []testItem{
+				{Priority: 2, Value: "A"},
+				{Priority: 5, Value: "B"},
+				{Priority: 3, Value: "C"},
+				{Priority: 1, Value: "D"},
+				{Priority: 4, Value: "E"},
+			},
+			expected: "{5 B}\n|---0: {4 E}\n|   `---0: {2 A}\n|---1: {3 C}\n`---2: {1 D}\n",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			dh := From(3, tc.items)
+
+			var sb strings.Builder
+			a.Nil(dh.Print(&sb))
+			a.Equal(tc.expected, sb.String())
+		})
+	}
+}
+
+func TestFuncHeap_Print(t *testing.T) {
+	cases := map[string]struct {
+		items    []int
+		expected string
+	}{
+		"print a binary heap": {
+			items:    []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expected: "1\n|---0: 2\n|   |---0: 4\n|   |   |---0: 8\n|   |   `---1: 9\n|   `---1: 5\n|       `---0: 10\n`---1: 3\n    |---0: 6\n    `---1: 7\n",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			h := NewFunc(2, tc.items, func(i int) int {
+				return i
+			}, func(a, b int) bool {
+				return a < b
+			})
+
+			var sb strings.Builder
+			a.Nil(h.Print(&sb))
+			a.Equal(tc.expected, sb.String())
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package heap
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Quantile tracks the q-quantile of nodes ordered by Node.Less, using the nearest rank ceil(q*n). The lower heap is a
+// max heap of the nodes up to the rank, and the upper heap is a min heap of the rest, so the quantile is the top of
+// the lower heap. Nodes can be removed by id, which allows sliding windows.
+type Quantile[T Node] struct {
+	q     float64
+	lower *FuncHeap[T, string]
+	upper *FuncHeap[T, string]
+	mu    sync.RWMutex
+}
+
+func (qt *Quantile[T]) Len() int {
+	qt.mu.RLock()
+	defer qt.mu.RUnlock()
+
+	return qt.lower.Len() + qt.upper.Len()
+}
+
+// Value returns the node at the quantile, it returns false when there is no node.
+func (qt *Quantile[T]) Value() (T, bool) {
+	qt.mu.RLock()
+	defer qt.mu.RUnlock()
+
+	return qt.lower.Peek()
+}
+
+func (qt *Quantile[T]) Add(node T) error {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	id := node.GetUniqueID()
+	if qt.contains(id) {
+		return itemAlreadyExistsError(id)
+	}
+
+	if top, ok := qt.lower.Peek(); !ok || !top.Less(node) {
+		_ = qt.lower.Push(node)
+	} else {
+		_ = qt.upper.Push(node)
+	}
+
+	qt.rebalance()
+	return nil
+}
+
+func (qt *Quantile[T]) Remove(id string) (T, bool) {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	node, ok := qt.lower.Remove(id)
+	if !ok {
+		node, ok = qt.upper.Remove(id)
+	}
+
+	if ok {
+		qt.rebalance()
+	}
+
+	return node, ok
+}
+
+func (qt *Quantile[T]) contains(id string) bool {
+	if _, ok := qt.lower.Find(id); ok {
+		return true
+	}
+
+	_, ok := qt.upper.Find(id)
+	return ok
+}
+
+func (qt *Quantile[T]) rebalance() {
+	rank := qt.rank(qt.lower.Len() + qt.upper.Len())
+
+	for qt.lower.Len() > rank {
+		node, _ := qt.lower.Pop()
+		_ = qt.upper.Push(node)
+	}
+
+	for qt.lower.Len() < rank {
+		node, _ := qt.upper.Pop()
+		_ = qt.lower.Push(node)
+	}
+}
+
+// rank returns the nearest rank of n nodes, the epsilon stops q*n like 0.7*10 from rounding up to the next rank.
+func (qt *Quantile[T]) rank(n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(qt.q*float64(n) - 1e-9))
+	if rank < 1 {
+		return 1
+	}
+
+	return rank
+}
+
+// NewQuantile creates a Quantile tracking the q-quantile, q must be between 0 and 1.
+func NewQuantile[T Node](q float64) *Quantile[T] {
+	if !(q >= 0 && q <= 1) {
+		panic(invalidQuantileError(q))
+	}
+
+	return &Quantile[T]{
+		q: q,
+		lower: newFuncHeap(2, &[]T{}, nodeID[T], func(a, b T) bool {
+			return b.Less(a)
+		}),
+		upper: newFuncHeap(2, &[]T{}, nodeID[T], nodeLess[T]),
+	}
+}
+
+// NewMedian creates a Quantile tracking the median, the lower median for an even number of nodes.
+func NewMedian[T Node]() *Quantile[T] {
+	return NewQuantile[T](0.5)
+}
+
+func invalidQuantileError(q float64) error {
+	return fmt.Errorf(`q must be between 0 and 1, got %v`, q)
+}
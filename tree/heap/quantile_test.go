@@ -0,0 +1,197 @@
+package heap
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestQuantile_Add(t *testing.T) {
+	cases := map[string]struct {
+		q        float64
+		items    []latency
+		expected []int
+		err      error
+	}{
+		"running median": {
+			q:        0.5,
+			items:    []latency{{"a", 5}, {"b", 1}, {"c", 9}, {"d", 3}, {"e", 7}, {"f", 7}},
+			expected: []int{5, 1, 5, 3, 5, 5},
+		},
+		"running 90th percentile": {
+			q:        0.9,
+			items:    []latency{{"a", 5}, {"b", 1}, {"c", 9}, {"d", 3}, {"e", 7}},
+			expected: []int{5, 5, 9, 9, 9},
+		},
+		"running minimum": {
+			q:        0,
+			items:    []latency{{"a", 5}, {"b", 1}, {"c", 9}},
+			expected: []int{5, 1, 1},
+		},
+		"running maximum": {
+			q:        1,
+			items:    []latency{{"a", 5}, {"b", 1}, {"c", 9}},
+			expected: []int{5, 5, 9},
+		},
+		"add exists item": {
+			q:        0.5,
+			items:    []latency{{"a", 5}, {"a", 1}},
+			expected: []int{5, 5},
+			err:      errors.New("id a already exists"),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			qt := NewQuantile[latency](tc.q)
+
+			var err error
+			values := make([]int, 0)
+			for _, item := range tc.items {
+				if e := qt.Add(item); e != nil {
+					err = e
+				}
+
+				v, ok := qt.Value()
+				a.True(ok)
+				values = append(values, v.ms)
+			}
+
+			a.Equal(tc.err, err)
+			a.Equal(tc.expected, values)
+		})
+	}
+}
+
+func TestQuantile_Remove(t *testing.T) {
+	cases := map[string]struct {
+		items    []latency
+		remove   []string
+		expected []int
+	}{
+		"remove items": {
+			items:    []latency{{"a", 5}, {"b", 1}, {"c", 9}, {"d", 3}, {"e", 7}},
+			remove:   []string{"a", "x", "b", "c", "d", "e"},
+			expected: []int{3, 3, 7, 3, 7, -1},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			qt := NewMedian[latency]()
+			for _, item := range tc.items {
+				a.Nil(qt.Add(item))
+			}
+
+			values := make([]int, 0)
+			for _, id := range tc.remove {
+				qt.Remove(id)
+
+				v, ok := qt.Value()
+				if !ok {
+					values = append(values, -1)
+					continue
+				}
+
+				values = append(values, v.ms)
+			}
+
+			a.Equal(tc.expected, values)
+			a.Equal(0, qt.Len())
+		})
+	}
+}
+
+func TestQuantile_SlidingWindow(t *testing.T) {
+	cases := map[string]struct {
+		q      float64
+		window int
+		size   int
+	}{
+		"sliding median": {
+			q:      0.5,
+			window: 10,
+			size:   200,
+		},
+		"sliding 99th percentile": {
+			q:      0.99,
+			window: 100,
+			size:   500,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			qt := NewQuantile[latency](tc.q)
+			items := make([]latency, tc.size)
+
+			for i := range items {
+				items[i] = latency{id: strconv.Itoa(i), ms: rand.Intn(50)}
+				a.Nil(qt.Add(items[i]))
+
+				if i >= tc.window {
+					_, ok := qt.Remove(items[i-tc.window].id)
+					a.True(ok)
+				}
+
+				start := i - tc.window + 1
+				if start < 0 {
+					start = 0
+				}
+
+				window := make([]int, 0)
+				for _, item := range items[start : i+1] {
+					window = append(window, item.ms)
+				}
+				sort.Ints(window)
+
+				v, ok := qt.Value()
+				a.True(ok)
+				a.Equal(window[qt.rank(len(window))-1], v.ms)
+			}
+		})
+	}
+}
+
+func TestNewQuantile_InvalidQ(t *testing.T) {
+	cases := map[string]struct {
+		q   float64
+		err string
+	}{
+		"negative q": {
+			q:   -0.1,
+			err: "q must be between 0 and 1, got -0.1",
+		},
+		"q greater than 1": {
+			q:   1.5,
+			err: "q must be between 0 and 1, got 1.5",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.PanicsWithError(t, tc.err, func() {
+				NewQuantile[latency](tc.q)
+			})
+		})
+	}
+}
+
+type latency struct {
+	id string
+	ms int
+}
+
+func (l latency) GetUniqueID() string {
+	return l.id
+}
+
+func (l latency) Less(data Node) bool {
+	return l.ms < data.(latency).ms
+}
@@ -0,0 +1,58 @@
+package heap
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// PriorityQueue is the set of operations shared by DHeap and the mergeable heaps.
+type PriorityQueue[T Node] interface {
+	Len() int
+	Peek() Node
+	Find(id string) (Node, error)
+	Pop() Node
+	Push(node T) error
+	Update(id string, updates func(old T) T) error
+	Remove(id string) (Node, error)
+}
+
+// lockPair locks a and b in the order of their addresses and returns the function unlocking them.
+func lockPair(a, b *sync.RWMutex) func() {
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+
+	a.Lock()
+	b.Lock()
+
+	return func() {
+		b.Unlock()
+		a.Unlock()
+	}
+}
+
+// meldIndex merges the smaller id index into the larger one, it fails without changes on duplicated ids. The mergeable
+// heaps link their trees in O(1) or O(log n) in Meld, but unique ids need this index, so Meld takes O(min(n, m))
+// overall. Meld locks both heaps with lockPair, so a.Meld(b) and b.Meld(a) can run concurrently.
+func meldIndex[V any](a, b map[string]V) (map[string]V, error) {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+
+	for id := range b {
+		if _, ok := a[id]; ok {
+			return nil, itemAlreadyExistsError(id)
+		}
+	}
+
+	for id, v := range b {
+		a[id] = v
+	}
+
+	return a, nil
+}
+
+func meldItselfError() error {
+	return fmt.Errorf(`heap can not be melded with itself`)
+}
@@ -0,0 +1,369 @@
+package heap
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	cases := map[string]struct {
+		operation func(pq PriorityQueue[testItem]) error
+		expected  []testItem
+		err       error
+	}{
+		"push item": {
+			operation: func(pq PriorityQueue[testItem]) error {
+				return pq.Push(testItem{Priority: 6, Value: "F"})
+			},
+			expected: []testItem{
+				{Priority: 6, Value: "F"},
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+		"push exists item": {
+			operation: func(pq PriorityQueue[testItem]) error {
+				return pq.Push(testItem{Priority: 6, Value: "A"})
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+			err: errors.New("id A already exists"),
+		},
+		"increase priority": {
+			operation: func(pq PriorityQueue[testItem]) error {
+				return pq.Update("D", func(old testItem) testItem {
+					return testItem{Priority: old.Priority + 5, Value: old.Value}
+				})
+			},
+			expected: []testItem{
+				{Priority: 6, Value: "D"},
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+			},
+		},
+		"decrease priority and rename": {
+			operation: func(pq PriorityQueue[testItem]) error {
+				return pq.Update("B", func(old testItem) testItem {
+					return testItem{Priority: old.Priority - 5, Value: "B_Updated"}
+				})
+			},
+			expected: []testItem{
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+				{Priority: 0, Value: "B_Updated"},
+			},
+		},
+		"update item not found": {
+			operation: func(pq PriorityQueue[testItem]) error {
+				return pq.Update("F", func(old testItem) testItem {
+					return old
+				})
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+			err: errors.New("id F not found"),
+		},
+		"remove items": {
+			operation: func(pq PriorityQueue[testItem]) error {
+				for _, id := range []string{"B", "C"} {
+					if _, err := pq.Remove(id); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+			expected: []testItem{
+				{Priority: 4, Value: "E"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+		"remove item not found": {
+			operation: func(pq PriorityQueue[testItem]) error {
+				_, err := pq.Remove("F")
+				return err
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+			err: errors.New("id F not found"),
+		},
+	}
+
+	for name, newQueue := range getPriorityQueues() {
+		for n, tc := range cases {
+			t.Run(fmt.Sprintf("%v %v", name, n), func(t *testing.T) {
+				a := assert.New(t)
+				pq := newQueue(getTestItems())
+
+				a.Equal(tc.err, tc.operation(pq))
+				a.Equal(len(tc.expected), pq.Len())
+				a.Equal(tc.expected[0], pq.Peek())
+
+				items := make([]testItem, 0)
+				for pq.Len() > 0 {
+					items = append(items, pq.Pop().(testItem))
+				}
+
+				a.Equal(tc.expected, items)
+				a.Nil(pq.Pop())
+			})
+		}
+	}
+}
+
+func TestMergeableHeap_Meld(t *testing.T) {
+	cases := map[string]struct {
+		items         []testItem
+		expected      []testItem
+		expectedOther int
+		err           error
+	}{
+		"meld heaps": {
+			items: []testItem{
+				{Priority: 6, Value: "F"},
+				{Priority: 0, Value: "G"},
+			},
+			expected: []testItem{
+				{Priority: 6, Value: "F"},
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+				{Priority: 0, Value: "G"},
+			},
+		},
+		"meld empty heap": {
+			items: []testItem{},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+		},
+		"meld heap with duplicated id": {
+			items: []testItem{
+				{Priority: 6, Value: "F"},
+				{Priority: 0, Value: "A"},
+			},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+				{Priority: 2, Value: "A"},
+				{Priority: 1, Value: "D"},
+			},
+			expectedOther: 2,
+			err:           errors.New("id A already exists"),
+		},
+	}
+
+	for name, m := range getMergeableHeaps() {
+		for n, tc := range cases {
+			t.Run(fmt.Sprintf("%v %v", name, n), func(t *testing.T) {
+				a := assert.New(t)
+				h := m.new(getTestItems())
+				other := m.new(tc.items)
+
+				a.Equal(tc.err, m.meld(h, other))
+				a.Equal(tc.expectedOther, other.Len())
+				a.Equal(meldItselfError(), m.meld(h, h))
+
+				for _, item := range tc.expected {
+					node, err := h.Find(item.Value)
+					a.Equal(item, node)
+					a.Nil(err)
+				}
+
+				items := make([]testItem, 0)
+				for h.Len() > 0 {
+					items = append(items, h.Pop().(testItem))
+				}
+
+				a.Equal(tc.expected, items)
+			})
+		}
+	}
+}
+
+func TestMergeableHeap_ConcurrentMeld(t *testing.T) {
+	for name, m := range getMergeableHeaps() {
+		t.Run(name, func(t *testing.T) {
+			a := assert.New(t)
+
+			for i := 0; i < 1000; i++ {
+				h := m.new(getTestItems())
+				other := m.new([]testItem{{Priority: 6, Value: "F"}})
+
+				start := make(chan struct{})
+				var wg sync.WaitGroup
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					<-start
+					a.Nil(m.meld(h, other))
+				}()
+				go func() {
+					defer wg.Done()
+					<-start
+					a.Nil(m.meld(other, h))
+				}()
+				close(start)
+				wg.Wait()
+
+				a.Equal(6, h.Len()+other.Len())
+			}
+		})
+	}
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	graph := newBenchmarkGraph(2000, 16)
+
+	for name, newQueue := range getPriorityQueues() {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dijkstra(graph, newQueue(nil))
+			}
+		})
+	}
+}
+
+func getPriorityQueues() map[string]func(items []testItem) PriorityQueue[testItem] {
+	return map[string]func(items []testItem) PriorityQueue[testItem]{
+		"binary heap": func(items []testItem) PriorityQueue[testItem] {
+			return New(2, &items)
+		},
+		"4-ary heap": func(items []testItem) PriorityQueue[testItem] {
+			return New(4, &items)
+		},
+		"pairing heap": func(items []testItem) PriorityQueue[testItem] {
+			return NewPairing(items)
+		},
+		"binomial heap": func(items []testItem) PriorityQueue[testItem] {
+			return NewBinomial(items)
+		},
+		"fibonacci heap": func(items []testItem) PriorityQueue[testItem] {
+			return NewFibonacci(items)
+		},
+	}
+}
+
+// mergeableHeap creates heaps of one type, and melds the second heap passed to meld into the first.
+type mergeableHeap struct {
+	new  func(items []testItem) PriorityQueue[testItem]
+	meld func(h, other PriorityQueue[testItem]) error
+}
+
+func getMergeableHeaps() map[string]mergeableHeap {
+	return map[string]mergeableHeap{
+		"pairing heap": {
+			new: func(items []testItem) PriorityQueue[testItem] {
+				return NewPairing(items)
+			},
+			meld: func(h, other PriorityQueue[testItem]) error {
+				return h.(*PairingHeap[testItem]).Meld(other.(*PairingHeap[testItem]))
+			},
+		},
+		"binomial heap": {
+			new: func(items []testItem) PriorityQueue[testItem] {
+				return NewBinomial(items)
+			},
+			meld: func(h, other PriorityQueue[testItem]) error {
+				return h.(*BinomialHeap[testItem]).Meld(other.(*BinomialHeap[testItem]))
+			},
+		},
+		"fibonacci heap": {
+			new: func(items []testItem) PriorityQueue[testItem] {
+				return NewFibonacci(items)
+			},
+			meld: func(h, other PriorityQueue[testItem]) error {
+				return h.(*FibonacciHeap[testItem]).Meld(other.(*FibonacciHeap[testItem]))
+			},
+		},
+	}
+}
+
+type benchmarkEdge struct {
+	to     int
+	weight int
+}
+
+func newBenchmarkGraph(vertices, degree int) [][]benchmarkEdge {
+	r := rand.New(rand.NewSource(1))
+	graph := make([][]benchmarkEdge, vertices)
+	for v := range graph {
+		for i := 0; i < degree; i++ {
+			graph[v] = append(graph[v], benchmarkEdge{to: r.Intn(vertices), weight: r.Intn(100) + 1})
+		}
+	}
+
+	return graph
+}
+
+// dijkstra uses testItem as (vertex, negative distance) so the shortest distance has the highest priority.
+func dijkstra(graph [][]benchmarkEdge, pq PriorityQueue[testItem]) []int {
+	distances := make([]int, len(graph))
+	for i := range distances {
+		distances[i] = -1
+	}
+
+	_ = pq.Push(testItem{Priority: 0, Value: "0"})
+	for pq.Len() > 0 {
+		current := pq.Pop().(testItem)
+		v, _ := strconv.Atoi(current.Value)
+		distances[v] = -current.Priority
+
+		for _, e := range graph[v] {
+			if distances[e.to] >= 0 {
+				continue
+			}
+
+			distance := distances[v] + e.weight
+			id := strconv.Itoa(e.to)
+			n, err := pq.Find(id)
+			if err != nil {
+				_ = pq.Push(testItem{Priority: -distance, Value: id})
+				continue
+			}
+
+			if -distance > n.(testItem).Priority {
+				_ = pq.Update(id, func(old testItem) testItem {
+					return testItem{Priority: -distance, Value: old.Value}
+				})
+			}
+		}
+	}
+
+	return distances
+}
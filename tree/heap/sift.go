@@ -0,0 +1,22 @@
+package heap
+
+// SiftDown sifts the item at i down the d-ary heap of the first n items and returns its final index. less(i, j)
+// reports whether the item at i belongs above the item at j, and swap swaps them, so any indexed collection can be
+// sifted in place. It is shared by FuncHeap and sort/heap.
+func SiftDown(d, i, n int, less func(i, j int) bool, swap func(i, j int)) int {
+	for {
+		top := i
+		for child := i*d + 1; child <= i*d+d && child < n; child++ {
+			if less(child, top) {
+				top = child
+			}
+		}
+
+		if top == i {
+			return i
+		}
+
+		swap(i, top)
+		i = top
+	}
+}
@@ -0,0 +1,62 @@
+package heap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSiftDown(t *testing.T) {
+	cases := map[string]struct {
+		d        int
+		i, n     int
+		items    []int
+		expected []int
+		idx      int
+	}{
+		"sift root of binary heap": {
+			d:        2,
+			n:        7,
+			items:    []int{9, 2, 3, 4, 5, 6, 7},
+			expected: []int{2, 4, 3, 9, 5, 6, 7},
+			idx:      3,
+		},
+		"sift root of ternary heap": {
+			d:        3,
+			n:        7,
+			items:    []int{9, 3, 2, 4, 5, 6, 7},
+			expected: []int{2, 3, 9, 4, 5, 6, 7},
+			idx:      2,
+		},
+		"sift within the first n items": {
+			d:        2,
+			n:        3,
+			items:    []int{9, 2, 3, 1},
+			expected: []int{2, 9, 3, 1},
+			idx:      1,
+		},
+		"item already in place": {
+			d:        2,
+			i:        1,
+			n:        4,
+			items:    []int{1, 2, 3, 4},
+			expected: []int{1, 2, 3, 4},
+			idx:      1,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			items := tc.items
+
+			idx := SiftDown(tc.d, tc.i, tc.n, func(i, j int) bool {
+				return items[i] < items[j]
+			}, func(i, j int) {
+				items[i], items[j] = items[j], items[i]
+			})
+
+			a.Equal(tc.idx, idx)
+			a.Equal(tc.expected, items)
+		})
+	}
+}
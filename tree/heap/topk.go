@@ -0,0 +1,105 @@
+package heap
+
+import (
+	"fmt"
+	"sync"
+)
+
+// TopK keeps the k best nodes offered, where a is better than b when a.Less(b). The nodes are kept in a heap of
+// reversed order, so the worst kept node is at the top and is evicted first.
+type TopK[T Node] struct {
+	k    int
+	heap *FuncHeap[T, string]
+	mu   sync.Mutex
+}
+
+func (t *TopK[T]) Len() int {
+	return t.heap.Len()
+}
+
+// Offer adds node when it is among the k best nodes, evicting the worst one, and reports whether node was kept.
+// A node with an existing id replaces the kept one only when it is better.
+func (t *TopK[T]) Offer(node T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.offer(node)
+}
+
+// Snapshot returns the kept nodes from the best to the worst.
+func (t *TopK[T]) Snapshot() []T {
+	nodes := t.heap.ToSortedSlice()
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+
+	return nodes
+}
+
+// Merge offers the kept nodes of others, so the collectors of partitioned streams can be combined. Nodes with the
+// same id are not summed, the better one is kept.
+func (t *TopK[T]) Merge(others ...*TopK[T]) {
+	for _, other := range others {
+		if other == t {
+			continue
+		}
+
+		nodes := other.heap.ToSortedSlice()
+
+		t.mu.Lock()
+		for _, node := range nodes {
+			t.offer(node)
+		}
+		t.mu.Unlock()
+	}
+}
+
+func (t *TopK[T]) offer(node T) bool {
+	id := node.GetUniqueID()
+	if kept, ok := t.heap.Find(id); ok {
+		if !node.Less(kept) {
+			return false
+		}
+
+		_ = t.heap.Update(id, func(T) T {
+			return node
+		})
+
+		return true
+	}
+
+	if t.heap.Len() < t.k {
+		_ = t.heap.Push(node)
+		return true
+	}
+
+	worst, _ := t.heap.Peek()
+	if !node.Less(worst) {
+		return false
+	}
+
+	_ = t.heap.Update(worst.GetUniqueID(), func(T) T {
+		return node
+	})
+
+	return true
+}
+
+// NewTopK creates a TopK keeping at most k nodes, k must be positive.
+func NewTopK[T Node](k int) *TopK[T] {
+	if k < 1 {
+		panic(invalidTopKError(k))
+	}
+
+	nodes := make([]T, 0, k)
+	return &TopK[T]{
+		k: k,
+		heap: newFuncHeap(2, &nodes, nodeID[T], func(a, b T) bool {
+			return b.Less(a)
+		}),
+	}
+}
+
+func invalidTopKError(k int) error {
+	return fmt.Errorf(`k must be positive, got %v`, k)
+}
@@ -0,0 +1,188 @@
+package heap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestTopK_Offer(t *testing.T) {
+	cases := map[string]struct {
+		k        int
+		items    []testItem
+		kept     []bool
+		expected []testItem
+	}{
+		"keep k best items": {
+			k: 3,
+			items: []testItem{
+				{Priority: 2, Value: "A"},
+				{Priority: 5, Value: "B"},
+				{Priority: 3, Value: "C"},
+				{Priority: 1, Value: "D"},
+				{Priority: 4, Value: "E"},
+			},
+			kept: []bool{true, true, true, false, true},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 4, Value: "E"},
+				{Priority: 3, Value: "C"},
+			},
+		},
+		"keep fewer items than k": {
+			k: 10,
+			items: []testItem{
+				{Priority: 2, Value: "A"},
+				{Priority: 5, Value: "B"},
+			},
+			kept: []bool{true, true},
+			expected: []testItem{
+				{Priority: 5, Value: "B"},
+				{Priority: 2, Value: "A"},
+			},
+		},
+		"replace kept item": {
+			k: 2,
+			items: []testItem{
+				{Priority: 2, Value: "A"},
+				{Priority: 5, Value: "B"},
+				{Priority: 3, Value: "C"},
+				{Priority: 9, Value: "C"},
+			},
+			kept: []bool{true, true, true, true},
+			expected: []testItem{
+				{Priority: 9, Value: "C"},
+				{Priority: 5, Value: "B"},
+			},
+		},
+		"keep better item with the same id": {
+			k: 2,
+			items: []testItem{
+				{Priority: 5, Value: "A"},
+				{Priority: 3, Value: "B"},
+				{Priority: 1, Value: "A"},
+				{Priority: 5, Value: "A"},
+			},
+			kept: []bool{true, true, false, false},
+			expected: []testItem{
+				{Priority: 5, Value: "A"},
+				{Priority: 3, Value: "B"},
+			},
+		},
+		"evict equal item": {
+			k: 1,
+			items: []testItem{
+				{Priority: 2, Value: "A"},
+				{Priority: 2, Value: "B"},
+			},
+			kept: []bool{true, false},
+			expected: []testItem{
+				{Priority: 2, Value: "A"},
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			topK := NewTopK[testItem](tc.k)
+
+			kept := make([]bool, 0)
+			for _, item := range tc.items {
+				kept = append(kept, topK.Offer(item))
+			}
+
+			a.Equal(tc.kept, kept)
+			a.Equal(len(tc.expected), topK.Len())
+			a.Equal(tc.expected, topK.Snapshot())
+		})
+	}
+}
+
+func TestTopK_Merge(t *testing.T) {
+	cases := map[string]struct {
+		k          int
+		partitions [][]testItem
+		expected   []testItem
+	}{
+		"merge partitions": {
+			k: 3,
+			partitions: [][]testItem{
+				{{Priority: 2, Value: "A"}, {Priority: 7, Value: "B"}, {Priority: 3, Value: "C"}},
+				{{Priority: 1, Value: "D"}, {Priority: 4, Value: "E"}},
+				{{Priority: 6, Value: "F"}, {Priority: 8, Value: "G"}, {Priority: 0, Value: "H"}},
+			},
+			expected: []testItem{
+				{Priority: 8, Value: "G"},
+				{Priority: 7, Value: "B"},
+				{Priority: 6, Value: "F"},
+			},
+		},
+		"merge partitions with the same id": {
+			k: 3,
+			partitions: [][]testItem{
+				{{Priority: 9, Value: "A"}, {Priority: 1, Value: "B"}},
+				{{Priority: 2, Value: "A"}, {Priority: 3, Value: "C"}},
+			},
+			expected: []testItem{
+				{Priority: 9, Value: "A"},
+				{Priority: 3, Value: "C"},
+				{Priority: 1, Value: "B"},
+			},
+		},
+		"merge empty partitions": {
+			k:          3,
+			partitions: [][]testItem{{}, {}},
+			expected:   []testItem{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			collectors := make([]*TopK[testItem], len(tc.partitions))
+
+			var wg sync.WaitGroup
+			for i, partition := range tc.partitions {
+				collectors[i] = NewTopK[testItem](tc.k)
+
+				wg.Add(1)
+				go func(topK *TopK[testItem], items []testItem) {
+					defer wg.Done()
+					for _, item := range items {
+						topK.Offer(item)
+					}
+				}(collectors[i], partition)
+			}
+			wg.Wait()
+
+			topK := NewTopK[testItem](tc.k)
+			topK.Merge(append(collectors, topK)...)
+			a.Equal(tc.expected, topK.Snapshot())
+		})
+	}
+}
+
+func TestNewTopK_InvalidK(t *testing.T) {
+	cases := map[string]struct {
+		k   int
+		err string
+	}{
+		"zero k": {
+			k:   0,
+			err: "k must be positive, got 0",
+		},
+		"negative k": {
+			k:   -1,
+			err: "k must be positive, got -1",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			assert.PanicsWithError(t, tc.err, func() {
+				NewTopK[testItem](tc.k)
+			})
+		})
+	}
+}
@@ -79,6 +79,44 @@ func (n *Node[K, P]) search(key K) (*Node[K, P], error) {
 	return n, nil
 }
 
+func (n *Node[K, P]) scan(lower, upper K, bounded bool, visit func(node *Node[K, P])) {
+	if n.left != nil && n.key > lower {
+		n.left.scan(lower, upper, bounded, visit)
+	}
+
+	if bounded && n.key >= upper {
+		return
+	}
+
+	if n.key >= lower {
+		visit(n)
+	}
+
+	if n.right != nil {
+		n.right.scan(lower, upper, bounded, visit)
+	}
+}
+
+func (n *Node[K, P]) neighbours(key K) (floor, ceiling *Node[K, P]) {
+	for current := n; current != nil; {
+		if key < current.key {
+			ceiling = current
+			current = current.left
+			continue
+		}
+
+		if key > current.key {
+			floor = current
+			current = current.right
+			continue
+		}
+
+		return current, current
+	}
+
+	return floor, ceiling
+}
+
 func (n *Node[K, P]) insert(node *Node[K, P]) error {
 	if node.key > n.key {
 		if n.right == nil {
@@ -170,6 +208,54 @@ func (t *Treap[K, P]) Search(key K) (*Node[K, P], error) {
 	return t.root.search(key)
 }
 
+func (t *Treap[K, P]) ScanPrefix(prefix K) []*Node[K, P] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	nodes := make([]*Node[K, P], 0)
+	t.scanPrefix(prefix, func(node *Node[K, P]) {
+		nodes = append(nodes, node)
+	})
+
+	return nodes
+}
+
+func (t *Treap[K, P]) CountPrefix(prefix K) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	count := 0
+	t.scanPrefix(prefix, func(_ *Node[K, P]) {
+		count++
+	})
+
+	return count
+}
+
+func (t *Treap[K, P]) LongestCommonPrefix(key K) K {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.root == nil {
+		return ""
+	}
+
+	// the key sharing the longest prefix is always one of the sorted neighbours.
+	var prefix K
+	floor, ceiling := t.root.neighbours(key)
+	for _, n := range []*Node[K, P]{floor, ceiling} {
+		if n == nil {
+			continue
+		}
+
+		if p := commonPrefix(key, n.key); len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+
+	return prefix
+}
+
 func (t *Treap[K, P]) Print(w io.StringWriter) error {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -255,6 +341,15 @@ func (t *Treap[K, P]) Pop() *Node[K, P] {
 	return root
 }
 
+func (t *Treap[K, P]) scanPrefix(prefix K, visit func(node *Node[K, P])) {
+	if t.root == nil {
+		return
+	}
+
+	upper, bounded := prefixUpperBound(prefix)
+	t.root.scan(prefix, upper, bounded, visit)
+}
+
 func (t *Treap[K, P]) up(node *Node[K, P]) {
 	for parent := node.parent; parent != nil; parent = node.parent {
 		if t.less(parent, node) {
@@ -384,6 +479,28 @@ func New[K ~string, P constraints.Integer](less func(i, j *Node[K, P]) bool) *Tr
 	return &Treap[K, P]{less: less}
 }
 
+// prefixUpperBound returns the smallest key greater than every key starting with prefix,
+// bounded is false when no such key exists (empty prefix or prefix of 0xff bytes).
+func prefixUpperBound[K ~string](prefix K) (upper K, bounded bool) {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return K(b[:i+1]), true
+		}
+	}
+
+	return "", false
+}
+
+func commonPrefix[K ~string](a, b K) K {
+	i := 0
+	for ; i < len(a) && i < len(b) && a[i] == b[i]; i++ {
+	}
+
+	return a[:i]
+}
+
 func valueAlreadyExistsError(i string) error {
 	return fmt.Errorf(`value %v already exists`, i)
 }
@@ -293,6 +293,124 @@ func TestTreap_Pop(t *testing.T) {
 	}
 }
 
+func TestTreap_ScanPrefix(t *testing.T) {
+	cases := map[string]struct {
+		prefix   string
+		expected []string
+	}{
+		"scan tenant prefix": {
+			prefix:   "tenantA/",
+			expected: []string{"tenantA/queue1/job1", "tenantA/queue1/job2", "tenantA/queue2/job1"},
+		},
+		"scan queue prefix": {
+			prefix:   "tenantA/queue1/",
+			expected: []string{"tenantA/queue1/job1", "tenantA/queue1/job2"},
+		},
+		"scan exact key": {
+			prefix:   "tenantB/queue1/job1",
+			expected: []string{"tenantB/queue1/job1"},
+		},
+		"scan with empty prefix": {
+			prefix: "",
+			expected: []string{
+				"tenantA/queue1/job1",
+				"tenantA/queue1/job2",
+				"tenantA/queue2/job1",
+				"tenantAB/queue1/job1",
+				"tenantB/queue1/job1",
+			},
+		},
+		"scan prefix not exists": {
+			prefix:   "tenantC/",
+			expected: []string{},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			treap := setupPrefixTestData()
+
+			keys := make([]string, 0)
+			for _, node := range treap.ScanPrefix(tc.prefix) {
+				keys = append(keys, node.Key())
+			}
+
+			a.Equal(tc.expected, keys)
+			a.Equal(len(tc.expected), treap.CountPrefix(tc.prefix))
+		})
+	}
+}
+
+func TestTreap_CountPrefix(t *testing.T) {
+	cases := map[string]struct {
+		keys     []string
+		prefix   string
+		expected int
+	}{
+		"count prefix": {
+			keys:     []string{"a/1", "a/2", "ab/1", "b/1"},
+			prefix:   "a/",
+			expected: 2,
+		},
+		"count prefix ends with max byte": {
+			keys:     []string{"a\xff", "a\xff\x01", "b"},
+			prefix:   "a\xff",
+			expected: 2,
+		},
+		"count prefix in empty treap": {
+			prefix:   "a",
+			expected: 0,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			treap := New[string, int](func(i, j *Node[string, int]) bool {
+				return i.Priority() > j.Priority()
+			})
+
+			for i, k := range tc.keys {
+				_ = treap.Insert(NewNode(k, i))
+			}
+
+			a.Equal(tc.expected, treap.CountPrefix(tc.prefix))
+		})
+	}
+}
+
+func TestTreap_LongestCommonPrefix(t *testing.T) {
+	cases := map[string]struct {
+		key      string
+		expected string
+	}{
+		"key exists": {
+			key:      "tenantA/queue2/job1",
+			expected: "tenantA/queue2/job1",
+		},
+		"shared queue": {
+			key:      "tenantA/queue1/job3",
+			expected: "tenantA/queue1/job",
+		},
+		"shared tenant": {
+			key:      "tenantB/queue9",
+			expected: "tenantB/queue",
+		},
+		"no shared prefix": {
+			key:      "other",
+			expected: "",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+			a.Equal(tc.expected, setupPrefixTestData().LongestCommonPrefix(tc.key))
+		})
+	}
+}
+
 func TestTreap_Print(t *testing.T) {
 	cases := map[string]struct {
 		nodes    []*Node[string, int]
@@ -416,3 +534,23 @@ func TestTreap_Concurrent(t *testing.T) {
 		})
 	}
 }
+
+func setupPrefixTestData() *Treap[string, int] {
+	treap := New[string, int](func(i, j *Node[string, int]) bool {
+		return i.Priority() > j.Priority()
+	})
+
+	keys := []string{
+		"tenantB/queue1/job1",
+		"tenantA/queue1/job2",
+		"tenantAB/queue1/job1",
+		"tenantA/queue2/job1",
+		"tenantA/queue1/job1",
+	}
+
+	for i, k := range keys {
+		_ = treap.Insert(NewNode(k, i))
+	}
+
+	return treap
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strconv"
 )
 
 type Node interface {
@@ -14,15 +15,70 @@ type Node interface {
 	Right() Node
 }
 
+// NaryNode is a node with any number of children, nil children are skipped when printing.
+type NaryNode interface {
+	fmt.Stringer
+
+	Children() []NaryNode
+}
+
+type branch struct {
+	position string
+	node     fmt.Stringer
+}
+
 func Print(node Node, w io.StringWriter) error {
 	if isNil(node) {
 		return errors.New("empty")
 	}
 
-	return printTree(node, w, "", "", true)
+	return printTree(node, binaryBranches, w, "", "", true)
 }
 
-func printTree(node Node, w io.StringWriter, indent, position string, isLast bool) error {
+// PrintNary prints node like Print, the children are labelled by their index in Children.
+func PrintNary(node NaryNode, w io.StringWriter) error {
+	if isNil(node) {
+		return errors.New("empty")
+	}
+
+	return printTree(node, naryBranches, w, "", "", true)
+}
+
+func binaryBranches(node fmt.Stringer) []branch {
+	n := node.(Node)
+	branches := make([]branch, 0, 2)
+
+	if left := n.Left(); !isNil(left) {
+		branches = append(branches, branch{position: "L", node: left})
+	}
+
+	if right := n.Right(); !isNil(right) {
+		branches = append(branches, branch{position: "R", node: right})
+	}
+
+	return branches
+}
+
+func naryBranches(node fmt.Stringer) []branch {
+	children := node.(NaryNode).Children()
+	branches := make([]branch, 0, len(children))
+
+	for i, child := range children {
+		if !isNil(child) {
+			branches = append(branches, branch{position: strconv.Itoa(i), node: child})
+		}
+	}
+
+	return branches
+}
+
+func printTree(
+	node fmt.Stringer,
+	branches func(node fmt.Stringer) []branch,
+	w io.StringWriter,
+	indent, position string,
+	isLast bool,
+) error {
 	cornerSymbol := "|"
 	if isLast {
 		cornerSymbol = "`"
@@ -44,14 +100,9 @@ func printTree(node Node, w io.StringWriter, indent, position string, isLast boo
 		}
 	}
 
-	if left := node.Left(); !isNil(left) {
-		if err := printTree(left, w, indent, "L", isNil(node.Right())); err != nil {
-			return err
-		}
-	}
-
-	if right := node.Right(); !isNil(right) {
-		if err := printTree(right, w, indent, "R", true); err != nil {
+	children := branches(node)
+	for i, b := range children {
+		if err := printTree(b.node, branches, w, indent, b.position, i == len(children)-1); err != nil {
 			return err
 		}
 	}
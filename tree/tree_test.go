@@ -0,0 +1,113 @@
+package tree
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPrint(t *testing.T) {
+	cases := map[string]struct {
+		node     Node
+		expected string
+		err      error
+	}{
+		"print a nil node": {
+			node: (*binaryNode)(nil),
+			err:  errors.New("empty"),
+		},
+		"print a binary tree": {
+			node: &binaryNode{
+				value: "A",
+				left: &binaryNode{
+					value: "B",
+					right: &binaryNode{value: "D"},
+				},
+				right: &binaryNode{value: "C"},
+			},
+			expected: "A\n|---L: B\n|   `---R: D\n`---R: C\n",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			var sb strings.Builder
+			a.Equal(tc.err, Print(tc.node, &sb))
+			a.Equal(tc.expected, sb.String())
+		})
+	}
+}
+
+func TestPrintNary(t *testing.T) {
+	cases := map[string]struct {
+		node     NaryNode
+		expected string
+		err      error
+	}{
+		"print a nil node": {
+			node: (*naryNode)(nil),
+			err:  errors.New("empty"),
+		},
+		"print a n-ary tree": {
+			node: &naryNode{
+				value: "A",
+				children: []NaryNode{
+					&naryNode{
+						value:    "B",
+						children: []NaryNode{&naryNode{value: "E"}, &naryNode{value: "F"}},
+					},
+					(*naryNode)(nil),
+					&naryNode{value: "C"},
+					&naryNode{
+						value:    "D",
+						children: []NaryNode{nil, &naryNode{value: "G"}},
+					},
+				},
+			},
+			expected: "A\n|---0: B\n|   |---0: E\n|   `---1: F\n|---2: C\n`---3: D\n    `---1: G\n",
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			a := assert.New(t)
+
+			var sb strings.Builder
+			a.Equal(tc.err, PrintNary(tc.node, &sb))
+			a.Equal(tc.expected, sb.String())
+		})
+	}
+}
+
+type binaryNode struct {
+	value       string
+	left, right *binaryNode
+}
+
+func (n *binaryNode) String() string {
+	return n.value
+}
+
+func (n *binaryNode) Left() Node {
+	return n.left
+}
+
+func (n *binaryNode) Right() Node {
+	return n.right
+}
+
+type naryNode struct {
+	value    string
+	children []NaryNode
+}
+
+func (n *naryNode) String() string {
+	return n.value
+}
+
+func (n *naryNode) Children() []NaryNode {
+	return n.children
+}